@@ -0,0 +1,67 @@
+package cfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Extensions lists the configuration file extensions in lookup order.
+var Extensions = []string{".json", ".yaml", ".yml", ".toml"}
+
+type codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(b []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(b []byte, v interface{}) error {
+	return json.Unmarshal(b, v)
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Marshal(v interface{}) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+func (yamlCodec) Unmarshal(b []byte, v interface{}) error {
+	return yaml.Unmarshal(b, v)
+}
+
+type tomlCodec struct{}
+
+func (tomlCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (tomlCodec) Unmarshal(b []byte, v interface{}) error {
+	return toml.Unmarshal(b, v)
+}
+
+// codecFor picks the codec by the file extension, JSON is used when the extension is unknown.
+func codecFor(file string) codec {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return yamlCodec{}
+	case ".toml":
+		return tomlCodec{}
+	default:
+		return jsonCodec{}
+	}
+}
@@ -0,0 +1,132 @@
+package cfile
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// EnvConfig is the environment variable that overrides the configuration file path.
+const EnvConfig = "ORC_CONFIG"
+
+var appName = "orc"
+var baseName = "config"
+var legacyFile = ".orc.conf.json"
+
+// Locate returns the configuration file path.
+//
+// The lookup order is the given override (--config flag), the ORC_CONFIG environment
+// variable, $XDG_CONFIG_HOME/orc/config.{json,yaml,yml,toml} and the legacy
+// $HOME/.orc.conf.json file. When none of them exists $XDG_CONFIG_HOME/orc/config.json is returned.
+func Locate(override string) string {
+	if override != "" {
+		return override
+	}
+
+	if env := os.Getenv(EnvConfig); env != "" {
+		return env
+	}
+
+	dir := filepath.Join(ConfigHome(), appName)
+
+	for _, ext := range Extensions {
+		file := filepath.Join(dir, baseName+ext)
+
+		if exists(file) {
+			return file
+		}
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		legacy := filepath.Join(home, legacyFile)
+
+		if exists(legacy) {
+			return legacy
+		}
+	}
+
+	return filepath.Join(dir, baseName+Extensions[0])
+}
+
+// ConfigHome returns $XDG_CONFIG_HOME, or $HOME/.config when it is not set.
+func ConfigHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+
+	home, _ := os.UserHomeDir()
+
+	return filepath.Join(home, ".config")
+}
+
+// StateFile returns the file in $XDG_STATE_HOME/orc, $XDG_STATE_HOME defaults to $HOME/.local/state.
+func StateFile(name string) string {
+	dir := os.Getenv("XDG_STATE_HOME")
+
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(dir, appName, name)
+}
+
+// CacheFile returns the file in $XDG_CACHE_HOME/orc, $XDG_CACHE_HOME defaults to $HOME/.cache.
+func CacheFile(name string) string {
+	dir := os.Getenv("XDG_CACHE_HOME")
+
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".cache")
+	}
+
+	return filepath.Join(dir, appName, name)
+}
+
+func exists(file string) bool {
+	_, err := os.Stat(file)
+
+	return err == nil
+}
+
+var systemDir = "/etc/orc"
+var projectName = ".orc"
+
+// SystemFile returns the existing system wide configuration file in /etc/orc, or an empty string.
+func SystemFile() string {
+	for _, ext := range Extensions {
+		file := filepath.Join(systemDir, baseName+ext)
+
+		if exists(file) {
+			return file
+		}
+	}
+
+	return ""
+}
+
+// ProjectFile walks up from the given directory and returns the first .orc.{json,yaml,yml,toml} file, or an empty string.
+func ProjectFile(dir string) string {
+	dir, err := filepath.Abs(dir)
+
+	if err != nil {
+		return ""
+	}
+
+	for {
+		for _, ext := range Extensions {
+			file := filepath.Join(dir, projectName+ext)
+
+			if exists(file) {
+				return file
+			}
+		}
+
+		parent := filepath.Dir(dir)
+
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
@@ -1,12 +1,15 @@
 package cfile
 
 import (
-	"encoding/json"
 	"io/fs"
 	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
 )
 
 var permission fs.FileMode = 0600
+var dirPermission fs.FileMode = 0700
 
 type IConfigFile interface {
 	// ConfigFile returns the configuration file path.
@@ -18,8 +21,11 @@ type IConfigFile interface {
 	// Reader reads the configuration file.
 	Reader() (IReader, error)
 
-	// Writer writes the given data to the configuration file.
+	// Writer writes the given data to the configuration file atomically.
 	Writer(data interface{}) (string, error)
+
+	// Lock acquires an advisory lock on the configuration file, the returned function releases it.
+	Lock() (func() error, error)
 }
 
 type IReader interface {
@@ -28,7 +34,8 @@ type IReader interface {
 }
 
 type ReaderResult struct {
-	b []byte
+	b     []byte
+	codec codec
 }
 
 type cfile struct {
@@ -60,20 +67,19 @@ func (r *cfile) Reader() (IReader, error) {
 	}
 
 	return &ReaderResult{
-		b: data,
+		b:     data,
+		codec: codecFor(r.file),
 	}, nil
 }
 
 func (r *cfile) Writer(data interface{}) (string, error) {
-	b, err := json.Marshal(&data)
+	b, err := codecFor(r.file).Marshal(&data)
 
 	if err != nil {
 		return "", err
 	}
 
-	err = os.WriteFile(r.file, b, permission)
-
-	if err != nil {
+	if err = writeAtomic(r.target(), b); err != nil {
 		return "", err
 	}
 
@@ -86,8 +92,90 @@ func (r *cfile) Writer(data interface{}) (string, error) {
 	return cur, nil
 }
 
+func (r *cfile) Lock() (func() error, error) {
+	if err := os.MkdirAll(filepath.Dir(r.file), dirPermission); err != nil {
+		return nil, err
+	}
+
+	// Every call gets its own lock file handle, flock(2) locks on different handles exclude each other
+	// even within the same process.
+	lock := flock.New(r.file + ".lock")
+
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+
+	return lock.Unlock, nil
+}
+
+// target resolves the configuration file when it is a symlink, so the rename replaces the real file.
+func (r *cfile) target() string {
+	if path, err := filepath.EvalSymlinks(r.file); err == nil {
+		return path
+	}
+
+	return r.file
+}
+
+// writeAtomic writes the data to a temporary file in the same directory, syncs it and renames it over the file.
+func writeAtomic(file string, b []byte) error {
+	dir := filepath.Dir(file)
+
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(file)+".tmp*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if err = tmp.Chmod(permission); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), file); err != nil {
+		return err
+	}
+
+	syncDir(dir)
+
+	return nil
+}
+
+// syncDir flushes the directory entry of the renamed file, it is not supported on every platform.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+
+	if err != nil {
+		return
+	}
+
+	defer d.Close()
+
+	_ = d.Sync()
+}
+
 func (rr *ReaderResult) Decode(d interface{}) error {
-	err := json.Unmarshal(rr.b, d)
+	err := rr.codec.Unmarshal(rr.b, d)
 
 	if err != nil {
 		return err
@@ -3,7 +3,10 @@ package cfile
 import (
 	"math"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	. "github.com/onsi/ginkgo/v2"
@@ -16,9 +19,9 @@ func TestCfile(t *testing.T) {
 }
 
 type Config struct {
-	APIKey              string   `json:"key"`
-	DefaultOrganization string   `json:"org"`
-	Organizations       []string `json:"orgs"`
+	APIKey              string   `json:"key" yaml:"key" toml:"key"`
+	DefaultOrganization string   `json:"org" yaml:"org" toml:"org"`
+	Organizations       []string `json:"orgs" yaml:"orgs" toml:"orgs"`
 }
 
 var _ = Describe("Config file service", func() {
@@ -143,4 +146,261 @@ var _ = Describe("Config file service", func() {
 			os.Remove(fileName)
 		})
 	})
+
+	Describe("Formats", func() {
+		for _, name := range []string{"test.yaml", "test.yml", "test.toml", "test.json"} {
+			name := name
+
+			It("should write and read back "+filepath.Ext(name), func() {
+				service := New(name)
+
+				conf := Config{
+					APIKey:              gofakeit.Word(),
+					DefaultOrganization: gofakeit.Word(),
+					Organizations:       []string{gofakeit.Word(), gofakeit.Word()},
+				}
+
+				_, err := service.Writer(conf)
+
+				Expect(err).To(BeNil())
+
+				result, err := service.Reader()
+
+				Expect(err).To(BeNil())
+
+				var newConf Config
+
+				Expect(result.Decode(&newConf)).To(BeNil())
+				Expect(newConf).To(Equal(conf))
+
+				os.Remove(name)
+			})
+		}
+
+		It("should write yaml content for yaml files", func() {
+			service := New("test.yaml")
+
+			_, err := service.Writer(Config{APIKey: "key", DefaultOrganization: "org"})
+
+			Expect(err).To(BeNil())
+
+			b, _ := os.ReadFile("test.yaml")
+
+			Expect(string(b)).To(ContainSubstring("org: org"))
+
+			os.Remove("test.yaml")
+		})
+
+		It("should create the parent directory", func() {
+			dir, _ := os.MkdirTemp("", "orc")
+			defer os.RemoveAll(dir)
+
+			service := New(filepath.Join(dir, "orc", "config.toml"))
+
+			_, err := service.Writer(Config{APIKey: "key"})
+
+			Expect(err).To(BeNil())
+			Expect(service.CheckConfigFile()).To(Equal(true))
+		})
+	})
+
+	Describe("Locate", func() {
+		var (
+			home   string
+			xdg    string
+			backup map[string]string
+		)
+
+		BeforeEach(func() {
+			home, _ = os.MkdirTemp("", "home")
+			xdg = filepath.Join(home, "xdg")
+			backup = map[string]string{}
+
+			for _, k := range []string{"HOME", "XDG_CONFIG_HOME", EnvConfig} {
+				backup[k] = os.Getenv(k)
+			}
+
+			os.Setenv("HOME", home)
+			os.Setenv("XDG_CONFIG_HOME", xdg)
+			os.Unsetenv(EnvConfig)
+		})
+
+		AfterEach(func() {
+			for k, v := range backup {
+				os.Setenv(k, v)
+			}
+
+			os.RemoveAll(home)
+		})
+
+		It("should return the override", func() {
+			os.Setenv(EnvConfig, "env.yaml")
+
+			Expect(Locate("flag.toml")).To(Equal("flag.toml"))
+		})
+
+		It("should return the environment variable", func() {
+			os.Setenv(EnvConfig, "env.yaml")
+
+			Expect(Locate("")).To(Equal("env.yaml"))
+		})
+
+		It("should return the default xdg file when nothing exists", func() {
+			Expect(Locate("")).To(Equal(filepath.Join(xdg, "orc", "config.json")))
+		})
+
+		It("should fall back to the legacy file", func() {
+			legacy := filepath.Join(home, ".orc.conf.json")
+			_ = os.WriteFile(legacy, []byte("{}"), 0600)
+
+			Expect(Locate("")).To(Equal(legacy))
+		})
+
+		It("should prefer the existing xdg file over the legacy file", func() {
+			_ = os.WriteFile(filepath.Join(home, ".orc.conf.json"), []byte("{}"), 0600)
+			_ = os.MkdirAll(filepath.Join(xdg, "orc"), 0700)
+			file := filepath.Join(xdg, "orc", "config.yaml")
+			_ = os.WriteFile(file, []byte(""), 0600)
+
+			Expect(Locate("")).To(Equal(file))
+		})
+
+		It("should use $HOME/.config without XDG_CONFIG_HOME", func() {
+			os.Unsetenv("XDG_CONFIG_HOME")
+
+			Expect(strings.HasPrefix(Locate(""), filepath.Join(home, ".config"))).To(Equal(true))
+		})
+	})
+
+	Describe("AtomicWriter", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir, _ = os.MkdirTemp("", "orc")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should not leave temporary files behind", func() {
+			service := New(filepath.Join(dir, "config.json"))
+
+			for i := 0; i < 5; i++ {
+				_, err := service.Writer(Config{APIKey: gofakeit.Word()})
+
+				Expect(err).To(BeNil())
+			}
+
+			entries, _ := os.ReadDir(dir)
+
+			Expect(len(entries)).To(Equal(1))
+		})
+
+		It("should keep the file permission", func() {
+			file := filepath.Join(dir, "config.json")
+
+			_, err := New(file).Writer(Config{APIKey: gofakeit.Word()})
+
+			Expect(err).To(BeNil())
+
+			info, _ := os.Stat(file)
+
+			Expect(info.Mode().Perm()).To(Equal(permission))
+		})
+
+		It("should write through the symlink", func() {
+			target := filepath.Join(dir, "real.json")
+			link := filepath.Join(dir, "link.json")
+
+			_ = os.WriteFile(target, []byte("{}"), 0600)
+			_ = os.Symlink(target, link)
+
+			_, err := New(link).Writer(Config{APIKey: "key"})
+
+			Expect(err).To(BeNil())
+
+			info, _ := os.Lstat(link)
+
+			Expect(info.Mode() & os.ModeSymlink).To(Equal(os.ModeSymlink))
+
+			b, _ := os.ReadFile(target)
+
+			Expect(string(b)).To(ContainSubstring("key"))
+		})
+	})
+
+	Describe("Lock", func() {
+		It("should block the second lock until the first one is released", func() {
+			dir, _ := os.MkdirTemp("", "orc")
+			defer os.RemoveAll(dir)
+
+			service := New(filepath.Join(dir, "config.json"))
+
+			unlock, err := service.Lock()
+
+			Expect(err).To(BeNil())
+
+			acquired := make(chan struct{})
+
+			go func() {
+				defer GinkgoRecover()
+
+				unlock, err := service.Lock()
+
+				Expect(err).To(BeNil())
+
+				close(acquired)
+
+				Expect(unlock()).To(BeNil())
+			}()
+
+			Consistently(acquired, 100*time.Millisecond).ShouldNot(BeClosed())
+
+			Expect(unlock()).To(BeNil())
+
+			Eventually(acquired).Should(BeClosed())
+		})
+	})
+
+	Describe("ProjectFile", func() {
+		It("should find the closest project file walking up", func() {
+			dir, _ := os.MkdirTemp("", "orc")
+			defer os.RemoveAll(dir)
+
+			sub := filepath.Join(dir, "a", "b")
+			_ = os.MkdirAll(sub, 0700)
+
+			file := filepath.Join(dir, "a", ".orc.yaml")
+			_ = os.WriteFile(file, []byte(""), 0600)
+			_ = os.WriteFile(filepath.Join(dir, ".orc.json"), []byte("{}"), 0600)
+
+			Expect(ProjectFile(sub)).To(Equal(file))
+		})
+
+		It("should return empty string without a project file", func() {
+			dir, _ := os.MkdirTemp("", "orc")
+			defer os.RemoveAll(dir)
+
+			Expect(ProjectFile(dir)).To(Equal(""))
+		})
+	})
+
+	Describe("SystemFile", func() {
+		It("should return the existing system file", func() {
+			dir, _ := os.MkdirTemp("", "orc")
+			defer os.RemoveAll(dir)
+
+			backup := systemDir
+			systemDir = dir
+			defer func() { systemDir = backup }()
+
+			Expect(SystemFile()).To(Equal(""))
+
+			file := filepath.Join(dir, "config.yaml")
+			_ = os.WriteFile(file, []byte(""), 0600)
+
+			Expect(SystemFile()).To(Equal(file))
+		})
+	})
 })
@@ -62,6 +62,21 @@ func (mr *MockIConfigFileMockRecorder) ConfigFile() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigFile", reflect.TypeOf((*MockIConfigFile)(nil).ConfigFile))
 }
 
+// Lock mocks base method.
+func (m *MockIConfigFile) Lock() (func() error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock")
+	ret0, _ := ret[0].(func() error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockIConfigFileMockRecorder) Lock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockIConfigFile)(nil).Lock))
+}
+
 // Reader mocks base method.
 func (m *MockIConfigFile) Reader() (cfile.IReader, error) {
 	m.ctrl.T.Helper()
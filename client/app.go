@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// jwtLifetime is kept below the 10 minutes maximum of GitHub, iat is backdated against clock drift.
+var jwtLifetime = 9 * time.Minute
+var jwtClockDrift = time.Minute
+
+// tokenRefreshMargin renews the installation token before it expires.
+var tokenRefreshMargin = time.Minute
+
+type appTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	apiURL         string
+	httpClient     *http.Client
+	now            func() time.Time
+}
+
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewAppTokenSource returns the token source minting installation tokens of the GitHub App.
+// apiURL is the REST API URL with the trailing slash like https://api.github.com/.
+func NewAppTokenSource(appID, installationID int64, privateKey []byte, apiURL string) (oauth2.TokenSource, error) {
+	key, err := parsePrivateKey(privateKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &appTokenSource{
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		apiURL:         apiURL,
+		httpClient:     http.DefaultClient,
+		now:            time.Now,
+	}, nil
+}
+
+// NewGithubAppClient returns the client authenticated as the installation of the GitHub App,
+// installation tokens are refreshed automatically before they expire.
+func NewGithubAppClient(appID, installationID int64, privateKey []byte, host string) (IGithubClient, error) {
+	ts, err := NewAppTokenSource(appID, installationID, privateKey, apiURL(host))
+
+	if err != nil {
+		return nil, err
+	}
+
+	ts = oauth2.ReuseTokenSource(nil, ts)
+
+	ghc, err := newGithubClient(ts, host)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ghc.app = &appInfo{appID: appID, installationID: installationID, ts: ts}
+
+	return ghc, nil
+}
+
+// Token exchanges a freshly signed JWT for an installation token.
+func (a *appTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := a.jwt()
+
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%d/access_tokens", a.apiURL, a.installationID)
+
+	req, err := http.NewRequest(http.MethodPost, url, http.NoBody)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := a.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("creating the installation token returned %s", resp.Status)
+	}
+
+	token := installationToken{}
+
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		TokenType:   "token",
+		Expiry:      token.ExpiresAt.Add(-tokenRefreshMargin),
+	}, nil
+}
+
+// jwt returns the RS256 signed JSON web token authenticating as the app.
+func (a *appTokenSource) jwt() (string, error) {
+	now := a.now()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-jwtClockDrift).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": a.appID,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := encodeSegment(header) + "." + encodeSegment(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, hash[:])
+
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + encodeSegment(signature), nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parsePrivateKey parses the PKCS#1 key downloaded from GitHub, PKCS#8 keys are accepted as well.
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(b))
+
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, fmt.Errorf("private key could not be parsed: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+// apiURL returns the REST API URL of the host, any host other than github.com is a GitHub Enterprise Server.
+func apiURL(host string) string {
+	if host == "" || host == defaultHost {
+		return "https://api.github.com/"
+	}
+
+	return "https://" + strings.TrimSuffix(host, "/") + "/api/v3/"
+}
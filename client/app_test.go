@@ -0,0 +1,144 @@
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("GitHub App token source", func() {
+	var (
+		key       *rsa.PrivateKey
+		keyPEM    []byte
+		server    *httptest.Server
+		minted    int
+		expiresIn time.Duration
+	)
+
+	// verifyJWT checks the signature and returns the claims of the token.
+	verifyJWT := func(token string) map[string]int64 {
+		parts := strings.Split(token, ".")
+
+		Expect(parts).To(HaveLen(3))
+
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+
+		Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature)).To(BeNil())
+
+		b, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		claims := map[string]int64{}
+
+		Expect(json.Unmarshal(b, &claims)).To(BeNil())
+
+		return claims
+	}
+
+	BeforeEach(func() {
+		key, _ = rsa.GenerateKey(rand.Reader, 2048)
+		keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+		minted = 0
+		expiresIn = time.Hour
+
+		mux := http.NewServeMux()
+		server = httptest.NewServer(mux)
+
+		mux.HandleFunc("/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.Method).To(Equal(http.MethodPost))
+
+			claims := verifyJWT(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+
+			Expect(claims["iss"]).To(Equal(int64(7)))
+			Expect(claims["exp"] - claims["iat"]).To(BeNumerically("<=", 600))
+
+			minted++
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":"%s"}`, minted,
+				time.Now().Add(expiresIn).UTC().Format(time.RFC3339))
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should exchange the JWT for the installation token", func() {
+		ts, err := NewAppTokenSource(7, 42, keyPEM, server.URL+"/")
+
+		Expect(err).To(BeNil())
+
+		token, err := ts.Token()
+
+		Expect(err).To(BeNil())
+		Expect(token.AccessToken).To(Equal("ghs_1"))
+		Expect(token.Expiry).To(BeTemporally("~", time.Now().Add(time.Hour-tokenRefreshMargin), 5*time.Second))
+	})
+
+	It("should reuse the token until it is about to expire", func() {
+		ts, _ := NewAppTokenSource(7, 42, keyPEM, server.URL+"/")
+		reuse := oauth2.ReuseTokenSource(nil, ts)
+
+		first, _ := reuse.Token()
+		second, _ := reuse.Token()
+
+		Expect(second.AccessToken).To(Equal(first.AccessToken))
+		Expect(minted).To(Equal(1))
+	})
+
+	It("should refresh the expired token", func() {
+		expiresIn = tokenRefreshMargin
+
+		ts, _ := NewAppTokenSource(7, 42, keyPEM, server.URL+"/")
+		reuse := oauth2.ReuseTokenSource(nil, ts)
+
+		first, _ := reuse.Token()
+		second, _ := reuse.Token()
+
+		Expect(first.AccessToken).To(Equal("ghs_1"))
+		Expect(second.AccessToken).To(Equal("ghs_2"))
+	})
+
+	It("should accept PKCS#8 keys", func() {
+		b, _ := x509.MarshalPKCS8PrivateKey(key)
+
+		_, err := NewAppTokenSource(7, 42, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}), server.URL+"/")
+
+		Expect(err).To(BeNil())
+	})
+
+	It("should return the error for an invalid key", func() {
+		_, err := NewAppTokenSource(7, 42, []byte("not a key"), server.URL+"/")
+
+		Expect(err).To(Not(BeNil()))
+	})
+
+	It("should return the error when the exchange fails", func() {
+		ts, _ := NewAppTokenSource(7, 404, keyPEM, server.URL+"/")
+
+		_, err := ts.Token()
+
+		Expect(err).To(Not(BeNil()))
+	})
+
+	It("should build the API URL of the host", func() {
+		Expect(apiURL("")).To(Equal("https://api.github.com/"))
+		Expect(apiURL("github.example.com")).To(Equal("https://github.example.com/api/v3/"))
+	})
+})
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// Token types reported by TokenInfo.
+const (
+	TokenClassic      = "classic personal access token"
+	TokenFineGrained  = "fine-grained personal access token"
+	TokenOAuth        = "OAuth token"
+	TokenUserToServer = "GitHub App user token"
+	TokenInstallation = "GitHub App installation token"
+	TokenUnknown      = "unknown"
+)
+
+var headerScopes = "X-OAuth-Scopes"
+var headerSSO = "X-GitHub-SSO"
+
+// impliedScopes lists the scopes that include the scope in the key.
+var impliedScopes = map[string][]string{
+	"read:org": {"write:org", "admin:org"},
+}
+
+var tokenPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{"ghp_", TokenClassic},
+	{"github_pat_", TokenFineGrained},
+	{"gho_", TokenOAuth},
+	{"ghu_", TokenUserToServer},
+	{"ghs_", TokenInstallation},
+}
+
+// TokenInfo describes the authenticated user and the token used by the client.
+type TokenInfo struct {
+	Login string
+	Type  string
+	// Scopes are the OAuth scopes of the token, ScopesKnown is false when the API does not report them
+	// like for fine-grained tokens.
+	Scopes      []string
+	ScopesKnown bool
+	// ExpiresAt is zero when the token does not expire.
+	ExpiresAt time.Time
+}
+
+// MissingScopes returns the required scopes that are not granted to the token.
+func (t *TokenInfo) MissingScopes(required ...string) []string {
+	if !t.ScopesKnown {
+		return nil
+	}
+
+	var missing []string
+
+	for _, scope := range required {
+		if !t.hasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
+
+func (t *TokenInfo) hasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+
+		for _, implied := range impliedScopes[scope] {
+			if s == implied {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (ghc *githubclient) Authenticated(ctx context.Context) (*TokenInfo, error) {
+	if ghc.app != nil {
+		return ghc.appAuthenticated(ctx)
+	}
+
+	var user *github.User
+	var resp *github.Response
+
+	err := ghc.do(ctx, func() (err error) {
+		user, resp, err = ghc.client.Users.Get(ctx, "")
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{
+		Login:     user.GetLogin(),
+		Type:      tokenType(ghc.key),
+		ExpiresAt: resp.TokenExpiration.Time,
+	}
+
+	if header, ok := resp.Header[http.CanonicalHeaderKey(headerScopes)]; ok {
+		info.ScopesKnown = true
+		info.Scopes = parseScopes(strings.Join(header, ","))
+	}
+
+	return info, nil
+}
+
+// appAuthenticated checks the installation token by listing the installation repositories,
+// the /user endpoint is not available for installation tokens.
+func (ghc *githubclient) appAuthenticated(ctx context.Context) (*TokenInfo, error) {
+	err := ghc.do(ctx, func() error {
+		_, _, err := ghc.client.Apps.ListRepos(ctx, &github.ListOptions{PerPage: 1})
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := ghc.app.ts.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenInfo{
+		Login:     fmt.Sprintf("app %d (installation %d)", ghc.app.appID, ghc.app.installationID),
+		Type:      TokenInstallation,
+		ExpiresAt: token.Expiry.Add(tokenRefreshMargin),
+	}, nil
+}
+
+func (ghc *githubclient) SSOAuthorization(ctx context.Context, org string) (string, error) {
+	org = ParseSource(org).Organization()
+
+	if org == "" {
+		return "", nil
+	}
+
+	opt := github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: 1},
+	}
+
+	_, resp, err := ghc.client.Repositories.ListByOrg(ctx, org, &opt)
+
+	var errResp *github.ErrorResponse
+
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		if url, ok := ssoURL(errResp.Response.Header.Get(headerSSO)); ok {
+			return url, nil
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if url, ok := ssoURL(resp.Header.Get(headerSSO)); ok {
+		return url, nil
+	}
+
+	return "", nil
+}
+
+// ssoURL parses the X-GitHub-SSO header, "required; url=<url>" means the token needs to be authorized.
+func ssoURL(header string) (string, bool) {
+	if !strings.HasPrefix(header, "required") {
+		return "", false
+	}
+
+	for _, part := range strings.Split(header, ";") {
+		if part = strings.TrimSpace(part); strings.HasPrefix(part, "url=") {
+			return strings.TrimPrefix(part, "url="), true
+		}
+	}
+
+	return "", true
+}
+
+func parseScopes(header string) []string {
+	var scopes []string
+
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func tokenType(key string) string {
+	for _, p := range tokenPrefixes {
+		if strings.HasPrefix(key, p.prefix) {
+			return p.kind
+		}
+	}
+
+	return TokenUnknown
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// CodeFile is a file matching the code search with the fragments of the matching text.
+type CodeFile struct {
+	Path      string
+	URL       string
+	Fragments []string
+}
+
+// CodeMatches are the files of the repository matching the code search.
+type CodeMatches struct {
+	Repository Repository
+	Files      []CodeFile
+}
+
+// SearchCode returns the files matching the code search grouped by repository in the order of the results,
+// and the number of matching files which is more than the returned files past the search cap.
+func (ghc *githubclient) SearchCode(ctx context.Context, query string) ([]CodeMatches, int, error) {
+	opt := &github.SearchOptions{
+		TextMatch:   true,
+		ListOptions: github.ListOptions{PerPage: pageSize},
+	}
+
+	var matches []CodeMatches
+
+	index := map[string]int{}
+	total, fetched := 0, 0
+
+	for {
+		var result *github.CodeSearchResult
+		var resp *github.Response
+
+		err := ghc.do(ctx, func() (err error) {
+			result, resp, err = ghc.client.Search.Code(ctx, query, opt)
+			return err
+		})
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		total = result.GetTotal()
+
+		for _, code := range result.CodeResults {
+			repo := code.GetRepository()
+
+			i, ok := index[repo.GetFullName()]
+
+			if !ok {
+				i = len(matches)
+				index[repo.GetFullName()] = i
+				matches = append(matches, CodeMatches{Repository: codeRepository(repo)})
+			}
+
+			file := CodeFile{
+				Path: code.GetPath(),
+				URL:  code.GetHTMLURL(),
+			}
+
+			for _, m := range code.TextMatches {
+				file.Fragments = append(file.Fragments, m.GetFragment())
+			}
+
+			matches[i].Files = append(matches[i].Files, file)
+		}
+
+		fetched += len(result.CodeResults)
+
+		if resp.NextPage == 0 || fetched >= searchCap {
+			return matches, total, nil
+		}
+
+		opt.Page = resp.NextPage
+	}
+}
+
+// codeRepository converts the repository of the code search, which has no clone URLs,
+// the SSH URL is built from the host of the repository page.
+func codeRepository(repo *github.Repository) Repository {
+	r := Repository{
+		ID:       repo.GetID(),
+		Name:     repo.GetName(),
+		Owner:    repo.GetOwner().GetLogin(),
+		Language: repo.GetLanguage(),
+		SSHUrl:   repo.GetSSHURL(),
+		URL:      repo.GetHTMLURL(),
+	}
+
+	if u, err := url.Parse(r.URL); err == nil && r.SSHUrl == "" && u.Host != "" {
+		r.SSHUrl = "git@" + u.Host + ":" + repo.GetFullName() + ".git"
+	}
+
+	return r
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Code search", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should group the files by repository with the text matches", func() {
+		mux.HandleFunc("/search/code", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Header.Get("Accept")).To(ContainSubstring("text-match"))
+			Expect(r.URL.Query().Get("q")).To(Equal("KAFKA_BROKERS org:acme"))
+
+			_, _ = w.Write([]byte(`{"total_count":3,"items":[
+				{"path":"config/app.yaml","html_url":"https://github.com/acme/api/blob/main/config/app.yaml",
+				 "repository":{"name":"api","full_name":"acme/api","owner":{"login":"acme"},"html_url":"https://github.com/acme/api"},
+				 "text_matches":[{"fragment":"KAFKA_BROKERS: kafka:9092"}]},
+				{"path":"worker/main.go","html_url":"https://github.com/acme/jobs/blob/main/worker/main.go",
+				 "repository":{"name":"jobs","full_name":"acme/jobs","owner":{"login":"acme"},"html_url":"https://github.com/acme/jobs"}},
+				{"path":".env","html_url":"https://github.com/acme/api/blob/main/.env",
+				 "repository":{"name":"api","full_name":"acme/api","owner":{"login":"acme"},"html_url":"https://github.com/acme/api"}}
+			]}`))
+		})
+
+		matches, total, err := newTestClient(server, "key").SearchCode(context.Background(), "KAFKA_BROKERS org:acme")
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(3))
+		Expect(matches).To(HaveLen(2))
+		Expect(matches[0].Repository.SSHUrl).To(Equal("git@github.com:acme/api.git"))
+		Expect(matches[0].Files).To(Equal([]CodeFile{
+			{Path: "config/app.yaml", URL: "https://github.com/acme/api/blob/main/config/app.yaml", Fragments: []string{"KAFKA_BROKERS: kafka:9092"}},
+			{Path: ".env", URL: "https://github.com/acme/api/blob/main/.env"},
+		}))
+		Expect(matches[1].Repository.Name).To(Equal("jobs"))
+	})
+})
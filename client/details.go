@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// topContributors is the number of contributors in the repository details.
+var topContributors = 5
+
+// RepositoryDetails is the repository with the metadata shown before cloning it.
+type RepositoryDetails struct {
+	Repository
+	Description   string
+	DefaultBranch string
+	// License is the SPDX id of the license, empty when the repository has none.
+	License string
+	Stars   int
+	Forks   int
+	// OpenIssues and OpenPulls are nil when the pull requests could not be counted.
+	OpenIssues *int
+	OpenPulls  *int
+	PushedAt   time.Time
+	// Contributors are the logins of the top contributors by the number of commits, empty when they could not be listed.
+	Contributors []string
+	// LatestRelease is the tag of the latest release, empty when the repository has none or it could not be read.
+	LatestRelease string
+	ReleasedAt    time.Time
+}
+
+// RepositoryDetails returns the repository with its contributors, latest release and open issue and
+// pull request counts, or ErrRepositoryNotFound when it is deleted or not visible to the token.
+// The details that cannot be read are left empty.
+func (ghc *githubclient) RepositoryDetails(ctx context.Context, owner, name string) (*RepositoryDetails, error) {
+	var repo *github.Repository
+
+	err := ghc.do(ctx, func() (err error) {
+		repo, _, err = ghc.client.Repositories.Get(ctx, owner, name)
+		return err
+	})
+
+	if notFound(err) {
+		return nil, ErrRepositoryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := &RepositoryDetails{
+		Repository:    newRepositoriesResult([]*github.Repository{repo}).Repositories[0],
+		Description:   repo.GetDescription(),
+		DefaultBranch: repo.GetDefaultBranch(),
+		License:       repo.GetLicense().GetSPDXID(),
+		Stars:         repo.GetStargazersCount(),
+		Forks:         repo.GetForksCount(),
+		PushedAt:      repo.GetPushedAt().Time,
+	}
+
+	// The side lookups are best effort, GitHub refuses the contributors of very large repositories
+	// and the search has a lower rate limit than the rest of the API.
+	d.Contributors, _ = ghc.contributors(ctx, owner, name)
+	d.LatestRelease, d.ReleasedAt, _ = ghc.latestRelease(ctx, owner, name)
+
+	if pulls, err := ghc.openPulls(ctx, owner, name); err == nil {
+		d.OpenPulls = &pulls
+
+		// The open issue count of the repository includes the pull requests.
+		issues := repo.GetOpenIssuesCount() - pulls
+
+		if issues < 0 {
+			issues = 0
+		}
+
+		d.OpenIssues = &issues
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (ghc *githubclient) contributors(ctx context.Context, owner, name string) ([]string, error) {
+	var contributors []*github.Contributor
+
+	err := ghc.do(ctx, func() (err error) {
+		contributors, _, err = ghc.client.Repositories.ListContributors(ctx, owner, name, &github.ListContributorsOptions{
+			ListOptions: github.ListOptions{PerPage: topContributors},
+		})
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("listing the contributors: %w", err)
+	}
+
+	logins := make([]string, len(contributors))
+
+	for i, c := range contributors {
+		logins[i] = c.GetLogin()
+	}
+
+	return logins, nil
+}
+
+// latestRelease returns the tag and the publish time of the latest release, an empty tag when there is none.
+func (ghc *githubclient) latestRelease(ctx context.Context, owner, name string) (string, time.Time, error) {
+	var release *github.RepositoryRelease
+
+	err := ghc.do(ctx, func() (err error) {
+		release, _, err = ghc.client.Repositories.GetLatestRelease(ctx, owner, name)
+		return err
+	})
+
+	if notFound(err) {
+		return "", time.Time{}, nil
+	}
+
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("getting the latest release: %w", err)
+	}
+
+	return release.GetTagName(), release.GetPublishedAt().Time, nil
+}
+
+// openPulls counts the open pull requests with the issue search, listing them would take a request per page.
+func (ghc *githubclient) openPulls(ctx context.Context, owner, name string) (int, error) {
+	var result *github.IssuesSearchResult
+
+	err := ghc.do(ctx, func() (err error) {
+		result, _, err = ghc.client.Search.Issues(ctx, fmt.Sprintf("repo:%s/%s is:pr is:open", owner, name), &github.SearchOptions{
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+		return err
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("counting the open pull requests: %w", err)
+	}
+
+	return result.GetTotal(), nil
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RepositoryDetails", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+
+		mux.HandleFunc("/repos/acme/api", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":42,"name":"api","owner":{"login":"acme"},"description":"The API",
+				"default_branch":"main","license":{"spdx_id":"MIT"},"stargazers_count":12,"forks_count":3,
+				"open_issues_count":7,"topics":["go"]}`))
+		})
+
+		mux.HandleFunc("/repos/acme/api/contributors", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("per_page")).To(Equal("5"))
+			_, _ = w.Write([]byte(`[{"login":"jane"},{"login":"joe"}]`))
+		})
+
+		mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("q") == "repo:acme/big is:pr is:open" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+				return
+			}
+
+			Expect(r.URL.Query().Get("q")).To(Equal("repo:acme/api is:pr is:open"))
+			_, _ = w.Write([]byte(`{"total_count":2,"items":[]}`))
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should collect the metadata and count the issues without the pull requests", func() {
+		mux.HandleFunc("/repos/acme/api/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.0","published_at":"2023-05-01T10:00:00Z"}`))
+		})
+
+		d, err := newTestClient(server, "key").RepositoryDetails(context.Background(), "acme", "api")
+
+		Expect(err).To(BeNil())
+		Expect(d.ID).To(Equal(int64(42)))
+		Expect(d.Topics).To(Equal([]string{"go"}))
+		Expect(d.Description).To(Equal("The API"))
+		Expect(d.DefaultBranch).To(Equal("main"))
+		Expect(d.License).To(Equal("MIT"))
+		Expect(d.Stars).To(Equal(12))
+		Expect(d.Forks).To(Equal(3))
+		Expect(d.Contributors).To(Equal([]string{"jane", "joe"}))
+		Expect(d.LatestRelease).To(Equal("v1.2.0"))
+		Expect(d.ReleasedAt.Year()).To(Equal(2023))
+		Expect(*d.OpenPulls).To(Equal(2))
+		Expect(*d.OpenIssues).To(Equal(5))
+	})
+
+	It("should leave the release empty when there is none", func() {
+		mux.HandleFunc("/repos/acme/api/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		d, err := newTestClient(server, "key").RepositoryDetails(context.Background(), "acme", "api")
+
+		Expect(err).To(BeNil())
+		Expect(d.LatestRelease).To(BeEmpty())
+	})
+
+	It("should leave the details empty that cannot be read", func() {
+		mux.HandleFunc("/repos/acme/big", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":7,"name":"big","owner":{"login":"acme"},"open_issues_count":7}`))
+		})
+
+		mux.HandleFunc("/repos/acme/big/contributors", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"The history or contributor list is too large to list contributors"}`))
+		})
+
+		mux.HandleFunc("/repos/acme/big/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Resource not accessible"}`))
+		})
+
+		d, err := newTestClient(server, "key").RepositoryDetails(context.Background(), "acme", "big")
+
+		Expect(err).To(BeNil())
+		Expect(d.ID).To(Equal(int64(7)))
+		Expect(d.Contributors).To(BeEmpty())
+		Expect(d.LatestRelease).To(BeEmpty())
+		Expect(d.OpenPulls).To(BeNil())
+		Expect(d.OpenIssues).To(BeNil())
+	})
+
+	It("should report the missing repository", func() {
+		mux.HandleFunc("/repos/acme/nope", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		_, err := newTestClient(server, "key").RepositoryDetails(context.Background(), "acme", "nope")
+
+		Expect(err).To(MatchError(ErrRepositoryNotFound))
+	})
+})
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var deviceCodePath = "/login/device/code"
+var accessTokenPath = "/login/oauth/access_token"
+var deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// slowDownInterval is added to the polling interval on every slow_down response.
+var slowDownInterval = 5 * time.Second
+var defaultInterval = 5 * time.Second
+
+// DeviceFlow implements the GitHub OAuth device authorization flow.
+type DeviceFlow struct {
+	ClientID string
+	Scopes   []string
+	// BaseURL is the web URL of the GitHub host like https://github.com.
+	BaseURL    string
+	HTTPClient *http.Client
+
+	wait func(ctx context.Context, d time.Duration, reason string) error
+}
+
+// DeviceCode is the code the user enters on the verification page.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// DeviceToken is the access token granted at the end of the flow.
+type DeviceToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+}
+
+type deviceResponse struct {
+	DeviceToken
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	Interval         int    `json:"interval"`
+}
+
+// NewDeviceFlow returns the device flow for the given OAuth app client id and GitHub host.
+func NewDeviceFlow(clientID, host string, scopes []string) *DeviceFlow {
+	if host == "" {
+		host = defaultHost
+	}
+
+	return &DeviceFlow{
+		ClientID:   clientID,
+		Scopes:     scopes,
+		BaseURL:    "https://" + host,
+		HTTPClient: http.DefaultClient,
+		wait:       Sleep,
+	}
+}
+
+// RequestCode requests the device and user codes.
+func (d *DeviceFlow) RequestCode(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{
+		"client_id": {d.ClientID},
+		"scope":     {strings.Join(d.Scopes, " ")},
+	}
+
+	resp := &struct {
+		DeviceCode
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+
+	if err := d.post(ctx, deviceCodePath, form, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s: %s", resp.Error, resp.ErrorDescription)
+	}
+
+	if resp.DeviceCode.DeviceCode == "" {
+		return nil, errors.New("device code is missing in the response")
+	}
+
+	return &resp.DeviceCode, nil
+}
+
+// PollToken polls the token endpoint until the user authorizes the device, denies it or the code expires.
+func (d *DeviceFlow) PollToken(ctx context.Context, code *DeviceCode) (*DeviceToken, error) {
+	interval := time.Duration(code.Interval) * time.Second
+
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	form := url.Values{
+		"client_id":   {d.ClientID},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {deviceGrantType},
+	}
+
+	for {
+		if code.ExpiresIn > 0 && time.Now().After(deadline) {
+			return nil, errors.New("device code expired, please try again")
+		}
+
+		if err := d.wait(ctx, interval, "authorization pending"); err != nil {
+			return nil, err
+		}
+
+		resp := &deviceResponse{}
+
+		if err := d.post(ctx, accessTokenPath, form, resp); err != nil {
+			return nil, err
+		}
+
+		switch resp.Error {
+		case "":
+			return &resp.DeviceToken, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += slowDownInterval
+
+			if resp.Interval > 0 {
+				interval = time.Duration(resp.Interval) * time.Second
+			}
+		case "expired_token":
+			return nil, errors.New("device code expired, please try again")
+		case "access_denied":
+			return nil, errors.New("authorization was denied")
+		default:
+			return nil, fmt.Errorf("%s: %s", resp.Error, resp.ErrorDescription)
+		}
+	}
+}
+
+func (d *DeviceFlow) post(ctx context.Context, path string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.BaseURL+path, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.HTTPClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", path, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Device flow", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+		flow   *DeviceFlow
+		waits  []time.Duration
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+		waits = nil
+
+		flow = NewDeviceFlow("client-id", "", []string{"repo", "read:org"})
+		flow.BaseURL = server.URL
+		flow.wait = func(ctx context.Context, d time.Duration, reason string) error {
+			waits = append(waits, d)
+			return nil
+		}
+
+		mux.HandleFunc(deviceCodePath, func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.FormValue("client_id")).To(Equal("client-id"))
+			Expect(r.FormValue("scope")).To(Equal("repo read:org"))
+
+			_, _ = w.Write([]byte(`{"device_code":"device","user_code":"ABCD-1234",` +
+				`"verification_uri":"https://github.com/login/device","expires_in":900,"interval":1}`))
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// respond serves the given token endpoint responses in order.
+	respond := func(responses ...string) {
+		calls := 0
+
+		mux.HandleFunc(accessTokenPath, func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.FormValue("device_code")).To(Equal("device"))
+			Expect(r.FormValue("grant_type")).To(Equal(deviceGrantType))
+
+			_, _ = w.Write([]byte(responses[calls]))
+			calls++
+		})
+	}
+
+	It("should default to github.com", func() {
+		Expect(NewDeviceFlow("id", "", nil).BaseURL).To(Equal("https://github.com"))
+		Expect(NewDeviceFlow("id", "github.example.com", nil).BaseURL).To(Equal("https://github.example.com"))
+	})
+
+	It("should request the device code", func() {
+		code, err := flow.RequestCode(context.Background())
+
+		Expect(err).To(BeNil())
+		Expect(code.UserCode).To(Equal("ABCD-1234"))
+		Expect(code.VerificationURI).To(Equal("https://github.com/login/device"))
+	})
+
+	It("should return the error of the device code request", func() {
+		mux = http.NewServeMux()
+		server.Config.Handler = mux
+
+		mux.HandleFunc(deviceCodePath, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"error":"device_flow_disabled","error_description":"Device Flow must be enabled"}`))
+		})
+
+		_, err := flow.RequestCode(context.Background())
+
+		Expect(err).To(MatchError("device_flow_disabled: Device Flow must be enabled"))
+	})
+
+	It("should poll until the token is granted and slow down when asked", func() {
+		respond(
+			`{"error":"authorization_pending"}`,
+			`{"error":"slow_down"}`,
+			`{"access_token":"gho_token","token_type":"bearer","scope":"repo,read:org"}`,
+		)
+
+		code, err := flow.RequestCode(context.Background())
+
+		Expect(err).To(BeNil())
+
+		token, err := flow.PollToken(context.Background(), code)
+
+		Expect(err).To(BeNil())
+		Expect(token.AccessToken).To(Equal("gho_token"))
+		Expect(waits).To(Equal([]time.Duration{time.Second, time.Second, 6 * time.Second}))
+	})
+
+	It("should return the error when the user denies the authorization", func() {
+		respond(`{"error":"authorization_pending"}`, `{"error":"access_denied"}`)
+
+		code, _ := flow.RequestCode(context.Background())
+
+		_, err := flow.PollToken(context.Background(), code)
+
+		Expect(err).To(MatchError("authorization was denied"))
+	})
+
+	It("should return the error when the code expires", func() {
+		respond(`{"error":"expired_token"}`)
+
+		code, _ := flow.RequestCode(context.Background())
+
+		_, err := flow.PollToken(context.Background(), code)
+
+		Expect(err).To(Not(BeNil()))
+	})
+
+	It("should return the error on unexpected status", func() {
+		mux.HandleFunc(accessTokenPath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		code, _ := flow.RequestCode(context.Background())
+
+		_, err := flow.PollToken(context.Background(), code)
+
+		Expect(err).To(Not(BeNil()))
+	})
+})
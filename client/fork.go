@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// forkPoll is the interval of the checks whether the fork can be cloned.
+var forkPoll = 2 * time.Second
+
+// forkTimeout is the longest wait for the fork, large repositories take minutes to fork.
+var forkTimeout = 5 * time.Minute
+
+// ErrForkTimeout is returned when the fork is created but not ready within the timeout.
+var ErrForkTimeout = errors.New("the fork is not ready yet, try cloning it later")
+
+// Fork creates the fork of the repository under the authenticated user, or under the organization when it is given,
+// and waits until it can be cloned. GitHub returns the existing fork when the repository is already forked there.
+func (ghc *githubclient) Fork(ctx context.Context, owner, name, org string) (*Repository, error) {
+	var fork *github.Repository
+
+	err := ghc.do(ctx, func() (err error) {
+		fork, _, err = ghc.client.Repositories.CreateFork(ctx, owner, name, &github.RepositoryCreateForkOptions{
+			Organization: org,
+		})
+		return err
+	})
+
+	// The fork is created in the background when GitHub accepts the request.
+	var accepted *github.AcceptedError
+
+	if errors.As(err, &accepted) {
+		err = nil
+	}
+
+	if notFound(err) {
+		return nil, ErrRepositoryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ghc.waitForFork(ctx, fork); err != nil {
+		return nil, err
+	}
+
+	return &newRepositoriesResult([]*github.Repository{fork}).Repositories[0], nil
+}
+
+// waitForFork waits until the default branch of the fork exists, the fork cannot be cloned before.
+func (ghc *githubclient) waitForFork(ctx context.Context, fork *github.Repository) error {
+	wait, cancel := context.WithTimeout(ctx, forkTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(forkPoll)
+	defer ticker.Stop()
+
+	for {
+		err := ghc.do(wait, func() (err error) {
+			_, _, err = ghc.client.Git.GetRef(wait, fork.GetOwner().GetLogin(), fork.GetName(), "heads/"+fork.GetDefaultBranch())
+			return err
+		})
+
+		if err == nil {
+			return nil
+		}
+
+		if wait.Err() == nil && !notFound(err) {
+			return fmt.Errorf("checking the fork: %w", err)
+		}
+
+		if wait.Err() == nil {
+			select {
+			case <-wait.Done():
+			case <-ticker.C:
+				continue
+			}
+		}
+
+		// The wait ends with the error of the caller when its context is done first.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return ErrForkTimeout
+	}
+}
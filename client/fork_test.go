@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fork", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+
+		poll, timeout := forkPoll, forkTimeout
+		forkPoll = time.Millisecond
+
+		DeferCleanup(func() {
+			forkPoll, forkTimeout = poll, timeout
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should create the fork in the organization and wait until it is ready", func() {
+		mux.HandleFunc("/repos/acme/api/forks", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+
+			var body map[string]string
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(HaveKeyWithValue("organization", "globex"))
+
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"id":43,"name":"api","owner":{"login":"globex"},"default_branch":"main",
+				"ssh_url":"git@github.com:globex/api.git"}`))
+		})
+
+		var checks atomic.Int32
+
+		mux.HandleFunc("/repos/globex/api/git/ref/heads/main", func(w http.ResponseWriter, r *http.Request) {
+			if checks.Add(1) < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Branch not found"}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"ref":"refs/heads/main"}`))
+		})
+
+		fork, err := newTestClient(server, "key").Fork(context.Background(), "acme", "api", "globex")
+
+		Expect(err).To(BeNil())
+		Expect(fork.ID).To(Equal(int64(43)))
+		Expect(fork.SSHUrl).To(Equal("git@github.com:globex/api.git"))
+		Expect(checks.Load()).To(Equal(int32(3)))
+	})
+
+	It("should give up when the fork is not ready in time", func() {
+		forkTimeout = 20 * time.Millisecond
+
+		mux.HandleFunc("/repos/acme/api/forks", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"name":"api","owner":{"login":"jane"},"default_branch":"main"}`))
+		})
+
+		mux.HandleFunc("/repos/jane/api/git/ref/heads/main", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		_, err := newTestClient(server, "key").Fork(context.Background(), "acme", "api", "")
+
+		Expect(err).To(MatchError(ErrForkTimeout))
+	})
+
+	It("should report the missing repository", func() {
+		mux.HandleFunc("/repos/acme/nope/forks", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		_, err := newTestClient(server, "key").Fork(context.Background(), "acme", "nope", "")
+
+		Expect(err).To(MatchError(ErrRepositoryNotFound))
+	})
+})
@@ -2,20 +2,99 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"path"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v52/github"
 	"golang.org/x/oauth2"
 )
 
-var pagination = 150
+// pageSize is the page size of the listings that follow every page, 100 is the maximum of the API.
+var pageSize = 100
+
+var defaultHost = "github.com"
+
+// IDConfig is the git configuration key of the clones storing the repository id,
+// the id identifies the repository after it is renamed or transferred.
+const IDConfig = "orc.id"
+
+// ErrRepositoryNotFound is returned when the repository does not exist or is not visible to the token.
+var ErrRepositoryNotFound = errors.New("repository not found")
 
 type IGithubClient interface {
-	Repositories(org string) (*RepositoriesResult, error)
+	// Repositories returns the repositories of the source, see ParseSource for the accepted sources.
+	Repositories(ctx context.Context, source string) (*RepositoriesResult, error)
+
+	// Authenticated returns the authenticated user and the details of the token.
+	Authenticated(ctx context.Context) (*TokenInfo, error)
+
+	// SSOAuthorization returns the URL to authorize the token when the organization of the source enforces
+	// SAML single sign-on and the token is not authorized yet, otherwise an empty string.
+	SSOAuthorization(ctx context.Context, org string) (string, error)
+
+	// Repository returns the repository, or ErrRepositoryNotFound when it is deleted or not visible to the token.
+	Repository(ctx context.Context, owner, name string) (*Repository, error)
+
+	// RepositoryByID returns the repository with the id wherever it is renamed or transferred to,
+	// or ErrRepositoryNotFound when it is deleted or not visible to the token.
+	RepositoryByID(ctx context.Context, id int64) (*Repository, error)
+
+	// RepositoryDetails returns the repository with the metadata shown before cloning it.
+	RepositoryDetails(ctx context.Context, owner, name string) (*RepositoryDetails, error)
+
+	// Readme returns the README of the repository, or ErrReadmeNotFound when it has none.
+	Readme(ctx context.Context, owner, name string) (string, error)
+
+	// PullRequests returns the open pull requests of the repository.
+	PullRequests(ctx context.Context, owner, name string) ([]PullRequest, error)
+
+	// SearchPullRequests returns the pull requests matching the issue search query and the number of matches.
+	SearchPullRequests(ctx context.Context, query string) ([]PullRequest, int, error)
+
+	// Fork creates the fork of the repository under the authenticated user or the organization and waits until it can be cloned.
+	Fork(ctx context.Context, owner, name, org string) (*Repository, error)
+
+	// Organizations returns the organizations the authenticated user is a member of.
+	Organizations(ctx context.Context) ([]string, error)
+
+	// Organization validates the organization and returns its login as spelled by GitHub.
+	Organization(ctx context.Context, org string) (string, error)
+
+	// SearchRepositories returns at most limit repositories matching the search query and the number of matches,
+	// which is more than the returned repositories when the limit is reached or the results could not be split
+	// under the search cap.
+	SearchRepositories(ctx context.Context, query string, limit int) (*RepositoriesResult, int, error)
+
+	// SearchCode returns the files matching the code search grouped by repository and the number of matches.
+	SearchCode(ctx context.Context, query string) ([]CodeMatches, int, error)
+
+	// Teams returns the teams of the organization.
+	Teams(ctx context.Context, org string) ([]Team, error)
+
+	// ResolveSource validates the source and returns it as spelled by GitHub.
+	ResolveSource(ctx context.Context, source string) (string, error)
+
+	// RateLimits returns the remaining quota of the API resources.
+	RateLimits(ctx context.Context) ([]RateLimit, error)
 }
 
 type githubclient struct {
 	client *github.Client
+	key    string
+	app    *appInfo
+	retry  RetryPolicy
+}
+
+// appInfo identifies the GitHub App installation of the clients authenticated as an app.
+type appInfo struct {
+	appID          int64
+	installationID int64
+	ts             oauth2.TokenSource
 }
 
 type RepositoriesResult struct {
@@ -23,58 +102,140 @@ type RepositoriesResult struct {
 }
 
 type Repository struct {
+	ID       int64
 	Name     string
+	Owner    string
 	Language string
 	SSHUrl   string
+	URL      string
+	Archived bool
+	Topics   []string
 }
 
 func NewGithubClient(key string) IGithubClient {
-	ctx := context.Background()
+	ghc, _ := NewGithubClientForHost(key, "")
+
+	return ghc
+}
 
+// NewGithubClientForHost returns the client for the given host, empty host or github.com uses the public API
+// and any other host is treated as a GitHub Enterprise Server.
+func NewGithubClientForHost(key, host string) (IGithubClient, error) {
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: key},
 	)
 
-	tc := oauth2.NewClient(ctx, ts)
-
-	client := github.NewClient(tc)
+	ghc, err := newGithubClient(ts, host)
 
-	return &githubclient{
-		client: client,
+	if err != nil {
+		return nil, err
 	}
+
+	ghc.key = key
+
+	return ghc, nil
 }
 
-func (ghc *githubclient) Repositories(org string) (*RepositoriesResult, error) {
+func newGithubClient(ts oauth2.TokenSource, host string) (*githubclient, error) {
 	ctx := context.Background()
 
-	opt := github.RepositoryListByOrgOptions{
-		ListOptions: github.ListOptions{PerPage: pagination},
+	tc := oauth2.NewClient(ctx, ts)
+
+	if host == "" || host == defaultHost {
+		return &githubclient{
+			client: github.NewClient(tc),
+			retry:  DefaultRetryPolicy,
+		}, nil
+	}
+
+	url := apiURL(host)
+
+	client, err := github.NewEnterpriseClient(url, strings.TrimSuffix(url, "v3/")+"uploads/", tc)
+
+	if err != nil {
+		return nil, err
 	}
 
-	repos, _, err := ghc.client.Repositories.ListByOrg(ctx, org, &opt)
+	return &githubclient{
+		client: client,
+		retry:  DefaultRetryPolicy,
+	}, nil
+}
+
+func (ghc *githubclient) Repositories(ctx context.Context, source string) (*RepositoriesResult, error) {
+	repos, err := ghc.listRepositories(ctx, ParseSource(source))
 
 	if err != nil {
 		return nil, err
 	}
 
+	return newRepositoriesResult(repos), nil
+}
+
+func newRepositoriesResult(repos []*github.Repository) *RepositoriesResult {
 	reps := make([]Repository, len(repos))
 
 	for i, repo := range repos {
 		reps[i] = Repository{
+			ID:       repo.GetID(),
 			Name:     repo.GetName(),
+			Owner:    repo.GetOwner().GetLogin(),
 			Language: repo.GetLanguage(),
 			SSHUrl:   repo.GetSSHURL(),
+			URL:      repo.GetHTMLURL(),
+			Archived: repo.GetArchived(),
+			Topics:   repo.Topics,
 		}
 	}
 
 	return &RepositoriesResult{
 		Repositories: reps,
-	}, nil
+	}
+}
+
+func (ghc *githubclient) Repository(ctx context.Context, owner, name string) (*Repository, error) {
+	var repo *github.Repository
+
+	err := ghc.do(ctx, func() (err error) {
+		repo, _, err = ghc.client.Repositories.Get(ctx, owner, name)
+		return err
+	})
+
+	if notFound(err) {
+		return nil, ErrRepositoryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newRepositoriesResult([]*github.Repository{repo}).Repositories[0], nil
+}
+
+func (ghc *githubclient) RepositoryByID(ctx context.Context, id int64) (*Repository, error) {
+	var repo *github.Repository
+
+	err := ghc.do(ctx, func() (err error) {
+		repo, _, err = ghc.client.Repositories.GetByID(ctx, id)
+		return err
+	})
+
+	if notFound(err) {
+		return nil, ErrRepositoryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newRepositoriesResult([]*github.Repository{repo}).Repositories[0], nil
 }
 
 func (r *RepositoriesResult) FindRepoByName(name string) Repository {
+	owners := r.multipleOwners()
+
 	for _, v := range r.Repositories {
-		if v.Name+" - "+v.Language == name {
+		if v.label(owners) == name {
 			return v
 		}
 	}
@@ -82,26 +243,160 @@ func (r *RepositoriesResult) FindRepoByName(name string) Repository {
 	return Repository{}
 }
 
+// RepositoryNames returns the names with the language, names are prefixed by the owner
+// when the repositories belong to different owners like the starred ones.
 func (r *RepositoriesResult) RepositoryNames() []string {
+	owners := r.multipleOwners()
 	names := make([]string, len(r.Repositories))
 
 	for i, v := range r.Repositories {
-		names[i] = v.Name + " - " + v.Language
+		names[i] = v.label(owners)
 	}
 
 	return names
 }
 
-func (r *Repository) Clone() error {
+func (r *RepositoriesResult) multipleOwners() bool {
+	for _, v := range r.Repositories {
+		if v.Owner != r.Repositories[0].Owner {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Repository) label(owner bool) string {
+	if owner {
+		return r.Owner + "/" + r.Name + " - " + r.Language
+	}
+
+	return r.Name + " - " + r.Language
+}
+
+// paginate follows every page of the listing.
+func paginate[T any](ctx context.Context, ghc *githubclient, list func(opt github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	opt := github.ListOptions{PerPage: pageSize}
+
+	var all []T
+
+	for {
+		var items []T
+		var resp *github.Response
+
+		err := ghc.do(ctx, func() (err error) {
+			items, resp, err = list(opt)
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+
+		opt.Page = resp.NextPage
+	}
+}
+
+// CloneOptions are the options of CloneTo, the zero value clones the default branch
+// with the full history into the current directory.
+type CloneOptions struct {
+	// Dir is the directory of the clone, the repository name in the current directory when empty.
+	Dir          string
+	Branch       string
+	Depth        int
+	SingleBranch bool
+}
+
+// Clone clones the repository into the current directory. When the clone fails or the context is cancelled,
+// the partially cloned directory is removed unless it existed before.
+func (r *Repository) Clone(ctx context.Context) error {
+	return r.CloneTo(ctx, CloneOptions{})
+}
+
+// CloneTo clones the repository with the options and records the repository id in its git configuration,
+// the failed clone is cleaned up like Clone.
+func (r *Repository) CloneTo(ctx context.Context, opt CloneOptions) error {
 	url := r.SSHUrl
+	dir := opt.Dir
 
-	cmd := exec.Command("git", "clone", url)
+	if dir == "" {
+		dir = cloneDir(url)
+	}
 
-	err := cmd.Run()
+	if dir == "" {
+		return fmt.Errorf("could not find the directory name of %s", url)
+	}
+
+	_, statErr := os.Stat(dir)
+	existed := statErr == nil
+
+	args := []string{"clone"}
+
+	if opt.Branch != "" {
+		args = append(args, "--branch", opt.Branch)
+	}
+
+	if opt.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opt.Depth))
+	}
+
+	if opt.SingleBranch {
+		args = append(args, "--single-branch")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", append(args, "--", url, dir)...)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if !existed {
+			os.RemoveAll(dir)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
 
-	if err != nil {
 		return err
 	}
 
+	if r.ID == 0 {
+		return nil
+	}
+
+	// A clone without the id cannot be followed after a rename, it is removed like a failed clone
+	// so the error never leaves a clone behind.
+	if out, err := exec.CommandContext(ctx, "git", "-C", dir, "config", IDConfig, strconv.FormatInt(r.ID, 10)).CombinedOutput(); err != nil {
+		if !existed {
+			os.RemoveAll(dir)
+		}
+
+		return fmt.Errorf("recording the repository id: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
 	return nil
 }
+
+// Dir returns the directory Clone clones the repository into.
+func (r *Repository) Dir() string {
+	return cloneDir(r.SSHUrl)
+}
+
+// cloneDir returns the directory name git uses for the URL, or an empty string when the URL has no name.
+func cloneDir(url string) string {
+	dir := strings.TrimSuffix(path.Base(strings.ReplaceAll(url, ":", "/")), ".git")
+
+	if dir == "." || dir == "/" || dir == ".." {
+		return ""
+	}
+
+	return dir
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v52/github"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Client Suite")
+}
+
+// newTestClient returns the client talking to the test server.
+func newTestClient(server *httptest.Server, key string) *githubclient {
+	client := github.NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return &githubclient{
+		client: client,
+		key:    key,
+		retry:  DefaultRetryPolicy,
+	}
+}
+
+var _ = Describe("Github client", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewGithubClientForHost", func() {
+		It("should use the public API for github.com", func() {
+			ghc, err := NewGithubClientForHost("key", "github.com")
+
+			Expect(err).To(BeNil())
+			Expect(ghc.(*githubclient).client.BaseURL.Host).To(Equal("api.github.com"))
+		})
+
+		It("should use the enterprise API for other hosts", func() {
+			ghc, err := NewGithubClientForHost("key", "github.example.com")
+
+			Expect(err).To(BeNil())
+			Expect(ghc.(*githubclient).client.BaseURL.String()).To(Equal("https://github.example.com/api/v3/"))
+		})
+	})
+
+	Describe("Authenticated", func() {
+		It("should return the token info", func() {
+			mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-OAuth-Scopes", "repo, admin:org")
+				w.Header().Set("GitHub-Authentication-Token-Expiration", "2030-01-02 03:04:05 UTC")
+				_, _ = w.Write([]byte(`{"login":"octocat"}`))
+			})
+
+			info, err := newTestClient(server, "ghp_abc").Authenticated(context.Background())
+
+			Expect(err).To(BeNil())
+			Expect(info.Login).To(Equal("octocat"))
+			Expect(info.Type).To(Equal(TokenClassic))
+			Expect(info.ScopesKnown).To(Equal(true))
+			Expect(info.Scopes).To(Equal([]string{"repo", "admin:org"}))
+			Expect(info.ExpiresAt.UTC().Year()).To(Equal(2030))
+			Expect(info.MissingScopes("repo", "read:org")).To(BeEmpty())
+		})
+
+		It("should not report the scopes when the header is missing", func() {
+			mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"login":"octocat"}`))
+			})
+
+			info, err := newTestClient(server, "github_pat_abc").Authenticated(context.Background())
+
+			Expect(err).To(BeNil())
+			Expect(info.Type).To(Equal(TokenFineGrained))
+			Expect(info.ScopesKnown).To(Equal(false))
+			Expect(info.ExpiresAt.IsZero()).To(Equal(true))
+			Expect(info.MissingScopes("repo")).To(BeEmpty())
+		})
+
+		It("should return the error for an invalid token", func() {
+			mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+			})
+
+			_, err := newTestClient(server, "bad").Authenticated(context.Background())
+
+			Expect(err).To(Not(BeNil()))
+		})
+	})
+
+	Describe("MissingScopes", func() {
+		It("should return the missing scopes", func() {
+			info := TokenInfo{Scopes: []string{"public_repo"}, ScopesKnown: true}
+
+			Expect(info.MissingScopes("repo", "read:org")).To(Equal([]string{"repo", "read:org"}))
+		})
+	})
+
+	Describe("SSOAuthorization", func() {
+		It("should return the authorization url", func() {
+			mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-GitHub-SSO", "required; url=https://github.com/orgs/acme/sso?authorization_request=1")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"Resource protected by organization SAML enforcement."}`))
+			})
+
+			url, err := newTestClient(server, "key").SSOAuthorization(context.Background(), "acme")
+
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("https://github.com/orgs/acme/sso?authorization_request=1"))
+		})
+
+		It("should return empty url when the token is authorized", func() {
+			mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[]`))
+			})
+
+			url, err := newTestClient(server, "key").SSOAuthorization(context.Background(), "acme")
+
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal(""))
+		})
+	})
+	Describe("Repository", func() {
+		It("should return the archived repository", func() {
+			mux.HandleFunc("/repos/acme/api", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"name":"api","owner":{"login":"acme"},"archived":true}`))
+			})
+
+			repo, err := newTestClient(server, "key").Repository(context.Background(), "acme", "api")
+
+			Expect(err).To(BeNil())
+			Expect(repo.Archived).To(BeTrue())
+		})
+
+		It("should find the renamed repository by its id", func() {
+			mux.HandleFunc("/repositories/42", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"id":42,"name":"web","owner":{"login":"globex"}}`))
+			})
+
+			repo, err := newTestClient(server, "key").RepositoryByID(context.Background(), 42)
+
+			Expect(err).To(BeNil())
+			Expect(repo.Owner + "/" + repo.Name).To(Equal("globex/web"))
+		})
+
+		It("should report the deleted repository", func() {
+			mux.HandleFunc("/repos/acme/gone", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			})
+
+			_, err := newTestClient(server, "key").Repository(context.Background(), "acme", "gone")
+
+			Expect(err).To(MatchError(ErrRepositoryNotFound))
+		})
+	})
+
+	Describe("Clone", func() {
+		It("should return the directory name of the url", func() {
+			Expect(cloneDir("git@github.com:acme/api.git")).To(Equal("api"))
+			Expect(cloneDir("https://github.com/acme/web")).To(Equal("web"))
+			Expect(cloneDir("")).To(Equal(""))
+		})
+
+		It("should record the repository id in the clone", func() {
+			dir := GinkgoT().TempDir()
+			upstream := filepath.Join(dir, "api.git")
+
+			Expect(exec.Command("git", "init", "-q", "--bare", upstream).Run()).To(Succeed())
+
+			repo := Repository{ID: 42, Name: "api", SSHUrl: upstream}
+
+			Expect(repo.CloneTo(context.Background(), CloneOptions{Dir: filepath.Join(dir, "api")})).To(Succeed())
+
+			out, err := exec.Command("git", "-C", filepath.Join(dir, "api"), "config", IDConfig).Output()
+
+			Expect(err).To(BeNil())
+			Expect(string(out)).To(Equal("42\n"))
+		})
+
+		It("should stop and leave no directory when the context is cancelled", func() {
+			wd, _ := os.Getwd()
+			Expect(os.Chdir(GinkgoT().TempDir())).To(Succeed())
+			DeferCleanup(os.Chdir, wd)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			repo := Repository{Name: "api", SSHUrl: "git@github.com:acme/api.git"}
+
+			Expect(repo.Clone(ctx)).To(MatchError(context.Canceled))
+			Expect("api").NotTo(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// ErrOrganizationNotFound is returned when the organization does not exist or is not visible to the token.
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+// Organizations returns the logins of the organizations the authenticated user is a member of.
+func (ghc *githubclient) Organizations(ctx context.Context) ([]string, error) {
+	orgs, err := paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Organization, *github.Response, error) {
+		return ghc.client.Organizations.List(ctx, "", &opt)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, len(orgs))
+
+	for i, org := range orgs {
+		logins[i] = org.GetLogin()
+	}
+
+	return logins, nil
+}
+
+// Organization returns the login of the organization as spelled by GitHub,
+// or ErrOrganizationNotFound when it does not exist.
+func (ghc *githubclient) Organization(ctx context.Context, org string) (string, error) {
+	var o *github.Organization
+
+	err := ghc.do(ctx, func() (err error) {
+		o, _, err = ghc.client.Organizations.Get(ctx, org)
+		return err
+	})
+
+	if notFound(err) {
+		return "", ErrOrganizationNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return o.GetLogin(), nil
+}
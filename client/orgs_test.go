@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Organizations", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should follow every page of the user organizations", func() {
+		mux.HandleFunc("/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"login":"globex"}]`))
+				return
+			}
+
+			w.Header().Set("Link", fmt.Sprintf(`<%s/user/orgs?page=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"login":"acme"},{"login":"initech"}]`))
+		})
+
+		orgs, err := newTestClient(server, "key").Organizations(context.Background())
+
+		Expect(err).To(BeNil())
+		Expect(orgs).To(Equal([]string{"acme", "initech", "globex"}))
+	})
+
+	It("should return the organization login as spelled by GitHub", func() {
+		mux.HandleFunc("/orgs/ACME", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"login":"acme"}`))
+		})
+
+		login, err := newTestClient(server, "key").Organization(context.Background(), "ACME")
+
+		Expect(err).To(BeNil())
+		Expect(login).To(Equal("acme"))
+	})
+
+	It("should report the missing organization", func() {
+		mux.HandleFunc("/orgs/nope", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		_, err := newTestClient(server, "key").Organization(context.Background(), "nope")
+
+		Expect(err).To(MatchError(ErrOrganizationNotFound))
+	})
+})
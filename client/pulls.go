@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// PullRequest is an open pull request of a repository.
+type PullRequest struct {
+	// Repository is the repository the pull request is opened to.
+	Repository Repository
+	Number     int
+	Title      string
+	Author     string
+	URL        string
+	// Draft is only set for the pull requests listed per repository, not for the search results.
+	Draft     bool
+	UpdatedAt time.Time
+}
+
+// PullRequests returns the open pull requests of the repository, the recently updated first.
+func (ghc *githubclient) PullRequests(ctx context.Context, owner, name string) ([]PullRequest, error) {
+	pulls, err := paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+		return ghc.client.PullRequests.List(ctx, owner, name, &github.PullRequestListOptions{
+			State:       "open",
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: opt,
+		})
+	})
+
+	if notFound(err) {
+		return nil, ErrRepositoryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PullRequest, len(pulls))
+
+	for i, pr := range pulls {
+		result[i] = PullRequest{
+			Repository: codeRepository(pr.GetBase().GetRepo()),
+			Number:     pr.GetNumber(),
+			Title:      pr.GetTitle(),
+			Author:     pr.GetUser().GetLogin(),
+			URL:        pr.GetHTMLURL(),
+			Draft:      pr.GetDraft(),
+			UpdatedAt:  pr.GetUpdatedAt().Time,
+		}
+	}
+
+	return result, nil
+}
+
+// SearchPullRequests returns the pull requests matching the issue search query, which should contain is:pr,
+// the recently updated first, and the number of matches.
+func (ghc *githubclient) SearchPullRequests(ctx context.Context, query string) ([]PullRequest, int, error) {
+	opt := &github.SearchOptions{
+		Sort:        "updated",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: pageSize},
+	}
+
+	var pulls []PullRequest
+
+	for {
+		var result *github.IssuesSearchResult
+		var resp *github.Response
+
+		err := ghc.do(ctx, func() (err error) {
+			result, resp, err = ghc.client.Search.Issues(ctx, query, opt)
+			return err
+		})
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, issue := range result.Issues {
+			pulls = append(pulls, PullRequest{
+				Repository: issueRepository(issue),
+				Number:     issue.GetNumber(),
+				Title:      issue.GetTitle(),
+				Author:     issue.GetUser().GetLogin(),
+				URL:        issue.GetHTMLURL(),
+				UpdatedAt:  issue.GetUpdatedAt().Time,
+			})
+		}
+
+		if resp.NextPage == 0 || len(pulls) >= searchCap {
+			return pulls, result.GetTotal(), nil
+		}
+
+		opt.Page = resp.NextPage
+	}
+}
+
+// issueRepository returns the repository of the search result, which only has the page URL of the
+// pull request like https://github.com/acme/api/pull/7, the other fields are left empty.
+func issueRepository(issue *github.Issue) Repository {
+	u, err := url.Parse(issue.GetHTMLURL())
+
+	if err != nil {
+		return Repository{}
+	}
+
+	path, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/pull/")
+	owner, name, _ := strings.Cut(path, "/")
+
+	return Repository{
+		Name:   name,
+		Owner:  owner,
+		SSHUrl: "git@" + u.Host + ":" + path + ".git",
+		URL:    u.Scheme + "://" + u.Host + "/" + path,
+	}
+}
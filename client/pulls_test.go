@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PullRequests", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list every page of the open pull requests", func() {
+		mux.HandleFunc("/repos/acme/api/pulls", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("state")).To(Equal("open"))
+
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"number":3,"title":"Bump deps","user":{"login":"bot"},"draft":true}]`))
+				return
+			}
+
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/api/pulls?state=open&page=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"number":7,"title":"Add retries","user":{"login":"jane"},"html_url":"https://github.com/acme/api/pull/7"}]`))
+		})
+
+		pulls, err := newTestClient(server, "key").PullRequests(context.Background(), "acme", "api")
+
+		Expect(err).To(BeNil())
+		Expect(pulls).To(HaveLen(2))
+		Expect(pulls[0]).To(Equal(PullRequest{Number: 7, Title: "Add retries", Author: "jane", URL: "https://github.com/acme/api/pull/7"}))
+		Expect(pulls[1].Draft).To(BeTrue())
+	})
+
+	It("should report the missing repository", func() {
+		mux.HandleFunc("/repos/acme/nope/pulls", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		_, err := newTestClient(server, "key").PullRequests(context.Background(), "acme", "nope")
+
+		Expect(err).To(MatchError(ErrRepositoryNotFound))
+	})
+
+	It("should search the pull requests across the repositories", func() {
+		mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("q")).To(Equal("is:pr is:open org:acme"))
+			Expect(r.URL.Query().Get("sort")).To(Equal("updated"))
+
+			_, _ = w.Write([]byte(`{"total_count":1,"items":[{"number":7,"title":"Add retries","user":{"login":"jane"},
+				"html_url":"https://github.com/acme/api/pull/7"}]}`))
+		})
+
+		pulls, total, err := newTestClient(server, "key").SearchPullRequests(context.Background(), "is:pr is:open org:acme")
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(1))
+		Expect(pulls).To(HaveLen(1))
+		Expect(pulls[0].Number).To(Equal(7))
+		Expect(pulls[0].Repository).To(Equal(Repository{
+			Name:   "api",
+			Owner:  "acme",
+			SSHUrl: "git@github.com:acme/api.git",
+			URL:    "https://github.com/acme/api",
+		}))
+	})
+})
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// ErrReadmeNotFound is returned when the repository has no README.
+var ErrReadmeNotFound = errors.New("readme not found")
+
+// Readme returns the content of the README of the default branch, or ErrReadmeNotFound when there is none.
+func (ghc *githubclient) Readme(ctx context.Context, owner, name string) (string, error) {
+	var readme *github.RepositoryContent
+
+	err := ghc.do(ctx, func() (err error) {
+		readme, _, err = ghc.client.Repositories.GetReadme(ctx, owner, name, nil)
+		return err
+	})
+
+	if notFound(err) {
+		return "", ErrReadmeNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return readme.GetContent()
+}
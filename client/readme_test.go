@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Readme", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should decode the README content", func() {
+		mux.HandleFunc("/repos/acme/api/readme", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"name":"README.md","encoding":"base64","content":"IyBBUEkK"}`))
+		})
+
+		readme, err := newTestClient(server, "key").Readme(context.Background(), "acme", "api")
+
+		Expect(err).To(BeNil())
+		Expect(readme).To(Equal("# API\n"))
+	})
+
+	It("should report the missing README", func() {
+		mux.HandleFunc("/repos/acme/api/readme", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		})
+
+		_, err := newTestClient(server, "key").Readme(context.Background(), "acme", "api")
+
+		Expect(err).To(MatchError(ErrReadmeNotFound))
+	})
+})
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// RetryPolicy controls how the client retries the failed requests.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries of the transient errors and rate limits.
+	MaxRetries int
+	// BaseDelay and MaxDelay bound the exponential backoff with full jitter.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxWait is the longest wait for a rate limit, longer waits return the rate limit error.
+	MaxWait time.Duration
+	// Wait waits for the given duration, it is replaced to show a countdown.
+	Wait func(ctx context.Context, d time.Duration, reason string) error
+}
+
+// DefaultRetryPolicy is used by the clients created afterwards.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+	MaxWait:    15 * time.Minute,
+	Wait:       Sleep,
+}
+
+// secondaryLimitWait is used when the secondary rate limit response has no Retry-After header.
+var secondaryLimitWait = time.Minute
+
+// rateLimitResetMargin is added to the reset time of the primary rate limit.
+var rateLimitResetMargin = time.Second
+
+// RateLimit is the quota of an API resource like core or search.
+type RateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Sleep waits for the duration or until the context is done.
+func Sleep(ctx context.Context, d time.Duration, reason string) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// do runs the request and retries it on transient errors and rate limits.
+func (ghc *githubclient) do(ctx context.Context, fn func() error) error {
+	p := ghc.retry
+	attempt := 0
+
+	for {
+		err := fn()
+
+		if err == nil {
+			return nil
+		}
+
+		wait, reason, limited := rateLimitWait(err)
+
+		if !limited {
+			if !transient(err) {
+				return err
+			}
+
+			wait, reason = p.backoff(attempt), fmt.Sprintf("request failed (%v)", err)
+		}
+
+		if attempt >= p.MaxRetries || wait > p.MaxWait {
+			return err
+		}
+
+		attempt++
+
+		if werr := p.Wait(ctx, wait, reason); werr != nil {
+			return werr
+		}
+	}
+}
+
+// backoff returns the exponential delay with full jitter for the attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+
+	if delay > p.MaxDelay || delay <= 0 {
+		delay = p.MaxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1)) //nolint:gosec
+}
+
+// rateLimitWait returns how long to wait when the error is a primary or secondary rate limit.
+func rateLimitWait(err error) (time.Duration, string, bool) {
+	var rateErr *github.RateLimitError
+
+	if errors.As(err, &rateErr) {
+		return time.Until(rateErr.Rate.Reset.Time) + rateLimitResetMargin, "primary rate limit exceeded", true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+
+	if errors.As(err, &abuseErr) {
+		if abuseErr.RetryAfter != nil {
+			return *abuseErr.RetryAfter, "secondary rate limit exceeded", true
+		}
+
+		return secondaryLimitWait, "secondary rate limit exceeded", true
+	}
+
+	return 0, "", false
+}
+
+// transient reports whether the error is a server or network error worth retrying.
+func transient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var errResp *github.ErrorResponse
+
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+func (ghc *githubclient) RateLimits(ctx context.Context) ([]RateLimit, error) {
+	var limits *github.RateLimits
+
+	err := ghc.do(ctx, func() (err error) {
+		limits, _, err = ghc.client.RateLimits(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := []RateLimit{}
+
+	for _, r := range []struct {
+		name string
+		rate *github.Rate
+	}{
+		{"core", limits.Core},
+		{"search", limits.Search},
+		{"graphql", limits.GraphQL},
+	} {
+		if r.rate != nil {
+			result = append(result, RateLimit{
+				Resource:  r.name,
+				Limit:     r.rate.Limit,
+				Remaining: r.rate.Remaining,
+				Reset:     r.rate.Reset.Time,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retries", func() {
+	var (
+		mux     *http.ServeMux
+		server  *httptest.Server
+		ghc     *githubclient
+		calls   int
+		waits   []time.Duration
+		reasons []string
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+		calls = 0
+		waits = nil
+		reasons = nil
+
+		ghc = newTestClient(server, "key")
+		ghc.retry.BaseDelay = time.Millisecond
+		// The client refuses the requests until the rate limit resets, so the waits really sleep.
+		ghc.retry.Wait = func(ctx context.Context, d time.Duration, reason string) error {
+			waits = append(waits, d)
+			reasons = append(reasons, reason)
+			return Sleep(ctx, d, reason)
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// serve answers with the handlers in order and with the last one afterwards.
+	serve := func(handlers ...http.HandlerFunc) {
+		mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+			i := calls
+
+			if i >= len(handlers) {
+				i = len(handlers) - 1
+			}
+
+			calls++
+			handlers[i](w, r)
+		})
+	}
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name":"orc"}]`))
+	}
+
+	status := func(code int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(code)
+		}
+	}
+
+	It("should retry the server errors with backoff", func() {
+		serve(status(http.StatusBadGateway), status(http.StatusServiceUnavailable), ok)
+
+		result, err := ghc.Repositories(context.Background(), "acme")
+
+		Expect(err).To(BeNil())
+		Expect(result.Repositories).To(HaveLen(1))
+		Expect(calls).To(Equal(3))
+		Expect(waits).To(HaveLen(2))
+
+		for i, wait := range waits {
+			Expect(wait).To(BeNumerically("<=", ghc.retry.BaseDelay<<i))
+		}
+	})
+
+	It("should give up after the maximum retries", func() {
+		serve(status(http.StatusInternalServerError))
+
+		_, err := ghc.Repositories(context.Background(), "acme")
+
+		Expect(err).To(Not(BeNil()))
+		Expect(calls).To(Equal(ghc.retry.MaxRetries + 1))
+	})
+
+	It("should not retry the client errors", func() {
+		serve(status(http.StatusNotFound))
+
+		_, err := ghc.Repositories(context.Background(), "acme")
+
+		Expect(err).To(Not(BeNil()))
+		Expect(calls).To(Equal(1))
+		Expect(waits).To(BeEmpty())
+	})
+
+	It("should wait until the reset of the primary rate limit", func() {
+		reset := time.Now().Add(time.Second)
+
+		serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+		}, ok)
+
+		_, err := ghc.Repositories(context.Background(), "acme")
+
+		Expect(err).To(BeNil())
+		Expect(waits).To(HaveLen(1))
+		Expect(waits[0]).To(BeNumerically(">", 0))
+		Expect(waits[0]).To(BeNumerically("<=", 2*time.Second+rateLimitResetMargin))
+		Expect(reasons[0]).To(Equal("primary rate limit exceeded"))
+	})
+
+	It("should honour Retry-After of the secondary rate limit", func() {
+		serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit",` +
+				`"documentation_url":"https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`))
+		}, ok)
+
+		_, err := ghc.Repositories(context.Background(), "acme")
+
+		Expect(err).To(BeNil())
+		Expect(waits).To(Equal([]time.Duration{time.Second}))
+		Expect(reasons[0]).To(Equal("secondary rate limit exceeded"))
+	})
+
+	It("should return the rate limit error when the wait is too long", func() {
+		serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", fmt.Sprint(int(ghc.retry.MaxWait.Seconds())+1))
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit",` +
+				`"documentation_url":"https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`))
+		})
+
+		_, err := ghc.Repositories(context.Background(), "acme")
+
+		Expect(err).To(Not(BeNil()))
+		Expect(waits).To(BeEmpty())
+	})
+
+	It("should stop when the context is cancelled", func() {
+		serve(status(http.StatusBadGateway))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ghc.retry.Wait = Sleep
+
+		_, err := ghc.Repositories(ctx, "acme")
+
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(calls).To(BeNumerically("<=", 1))
+	})
+
+	It("should return the rate limits", func() {
+		mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":4999,"reset":1700000000},` +
+				`"search":{"limit":30,"remaining":30,"reset":1700000000}}}`))
+		})
+
+		limits, err := ghc.RateLimits(context.Background())
+
+		Expect(err).To(BeNil())
+		Expect(limits).To(HaveLen(2))
+		Expect(limits[0].Resource).To(Equal("core"))
+		Expect(limits[0].Remaining).To(Equal(4999))
+		Expect(limits[1].Resource).To(Equal("search"))
+	})
+})
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// searchCap is the maximum number of results the search API returns for a query.
+var searchCap = 1000
+
+// searchStart is before the creation of the first repository, the start of the created date windows.
+var searchStart = time.Date(2007, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// searchMinWindow is the shortest created date window, the results of a shorter window are capped.
+var searchMinWindow = time.Hour
+
+var createdQualifier = "created:"
+
+// search collects the results of the query across the windows up to the limit, a repository is collected once.
+type search struct {
+	ghc   *githubclient
+	query string
+	limit int
+	repos []*github.Repository
+	seen  map[int64]bool
+}
+
+func (ghc *githubclient) SearchRepositories(ctx context.Context, query string, limit int) (*RepositoriesResult, int, error) {
+	s := &search{
+		ghc:   ghc,
+		query: query,
+		limit: limit,
+		seen:  map[int64]bool{},
+	}
+
+	var total int
+	var err error
+
+	// The results past the cap are reached by splitting the query on the creation date,
+	// which is not possible when the query already filters it.
+	if strings.Contains(query, createdQualifier) {
+		total, err = s.window(ctx, time.Time{}, time.Time{})
+	} else {
+		total, err = s.window(ctx, searchStart, time.Now().UTC().Truncate(time.Second))
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return newRepositoriesResult(s.repos), total, nil
+}
+
+// window collects the results of the repositories created between from and to, both included, and returns
+// the number of matches. Windows with more matches than the cap are split in halves while the results
+// still missing to reach the limit are more than the cap, every window costs a call of the rate limited API.
+func (s *search) window(ctx context.Context, from, to time.Time) (int, error) {
+	query := s.query
+
+	if !from.IsZero() {
+		query = fmt.Sprintf("%s %s%s..%s", query, createdQualifier, searchTime(from), searchTime(to))
+	}
+
+	opt := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: pageSize}}
+	total, fetched := 0, 0
+
+	for {
+		var result *github.RepositoriesSearchResult
+		var resp *github.Response
+
+		err := s.ghc.do(ctx, func() (err error) {
+			result, resp, err = s.ghc.client.Search.Repositories(ctx, query, opt)
+			return err
+		})
+
+		if err != nil {
+			return 0, err
+		}
+
+		if opt.Page == 0 {
+			total = result.GetTotal()
+
+			if total > searchCap && s.limit-len(s.repos) > searchCap && !from.IsZero() && to.Sub(from) > searchMinWindow {
+				mid := from.Add(to.Sub(from) / 2).Truncate(time.Second)
+
+				if _, err := s.window(ctx, from, mid); err != nil {
+					return 0, err
+				}
+
+				if s.full() {
+					return total, nil
+				}
+
+				if _, err := s.window(ctx, mid.Add(time.Second), to); err != nil {
+					return 0, err
+				}
+
+				return total, nil
+			}
+		}
+
+		for _, repo := range result.Repositories {
+			if !s.seen[repo.GetID()] && !s.full() {
+				s.seen[repo.GetID()] = true
+				s.repos = append(s.repos, repo)
+			}
+		}
+
+		fetched += len(result.Repositories)
+
+		if resp.NextPage == 0 || fetched >= searchCap || s.full() {
+			return total, nil
+		}
+
+		opt.Page = resp.NextPage
+	}
+}
+
+// full reports whether the limit of the results is reached.
+func (s *search) full() bool {
+	return len(s.repos) >= s.limit
+}
+
+// searchTime formats the time for the created qualifier.
+func searchTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05") + "+00:00"
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Search", func() {
+	var (
+		mux     *http.ServeMux
+		server  *httptest.Server
+		queries []string
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+		queries = nil
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should follow every page of the results", func() {
+		mux.HandleFunc("/search/repositories", func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.Query().Get("q"))
+
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`{"total_count":2,"items":[{"id":2,"name":"web","owner":{"login":"acme"}}]}`))
+				return
+			}
+
+			w.Header().Set("Link", fmt.Sprintf(`<%s/search/repositories?page=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`{"total_count":2,"items":[{"id":1,"name":"api","owner":{"login":"acme"}}]}`))
+		})
+
+		result, total, err := newTestClient(server, "key").SearchRepositories(context.Background(), "org:acme language:go", 1000)
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(2))
+		Expect(result.RepositoryNames()).To(Equal([]string{"api - ", "web - "}))
+		Expect(queries[0]).To(HavePrefix("org:acme language:go created:2007-10-01T00:00:00+00:00.."))
+	})
+
+	It("should split the query on the creation date past the search cap", func() {
+		mux.HandleFunc("/search/repositories", func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+
+			window := strings.Split(strings.SplitN(q, "created:", 2)[1], "..")
+			from, _ := time.Parse("2006-01-02T15:04:05-07:00", window[0])
+			to, _ := time.Parse("2006-01-02T15:04:05-07:00", window[1])
+
+			if to.Sub(from) > 5*365*24*time.Hour {
+				_, _ = w.Write([]byte(`{"total_count":1500,"items":[{"id":1,"name":"api","owner":{"login":"acme"}}]}`))
+				return
+			}
+
+			_, _ = fmt.Fprintf(w, `{"total_count":1,"items":[{"id":%d,"name":"repo-%d","owner":{"login":"acme"}}]}`,
+				from.Unix(), from.Year())
+		})
+
+		result, total, err := newTestClient(server, "key").SearchRepositories(context.Background(), "topic:kafka", 5000)
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(1500))
+		Expect(len(result.Repositories)).To(Equal(4))
+		Expect(queries).To(HaveLen(7))
+	})
+
+	It("should not split the query when the limit is within the search cap", func() {
+		mux.HandleFunc("/search/repositories", func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.Query().Get("q"))
+			_, _ = w.Write([]byte(`{"total_count":1500,"items":[{"id":1,"name":"api","owner":{"login":"acme"}},{"id":2,"name":"web","owner":{"login":"acme"}}]}`))
+		})
+
+		result, total, err := newTestClient(server, "key").SearchRepositories(context.Background(), "topic:kafka", 1)
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(1500))
+		Expect(result.RepositoryNames()).To(Equal([]string{"api - "}))
+		Expect(queries).To(HaveLen(1))
+	})
+
+	It("should stop splitting the query when the limit is reached", func() {
+		mux.HandleFunc("/search/repositories", func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+
+			window := strings.Split(strings.SplitN(q, "created:", 2)[1], "..")
+			from, _ := time.Parse("2006-01-02T15:04:05-07:00", window[0])
+			to, _ := time.Parse("2006-01-02T15:04:05-07:00", window[1])
+
+			total := 500
+
+			if to.Sub(from) > 365*24*time.Hour {
+				total = 5000
+			}
+
+			if page, _ := strconv.Atoi(r.URL.Query().Get("page")); page < 10 {
+				w.Header().Set("Link", fmt.Sprintf(`<%s/search/repositories?page=%d>; rel="next"`, server.URL, page+1))
+			}
+
+			items := make([]string, 100)
+
+			for i := range items {
+				items[i] = fmt.Sprintf(`{"id":%d,"name":"repo-%d","owner":{"login":"acme"}}`, len(queries)*100+i, len(queries)*100+i)
+			}
+
+			_, _ = fmt.Fprintf(w, `{"total_count":%d,"items":[%s]}`, total, strings.Join(items, ","))
+		})
+
+		result, total, err := newTestClient(server, "key").SearchRepositories(context.Background(), "language:go", 1200)
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(5000))
+		Expect(result.Repositories).To(HaveLen(1200))
+		Expect(len(queries)).To(BeNumerically("<", 20))
+	})
+
+	It("should not split the query filtering the creation date", func() {
+		mux.HandleFunc("/search/repositories", func(w http.ResponseWriter, r *http.Request) {
+			queries = append(queries, r.URL.Query().Get("q"))
+			_, _ = w.Write([]byte(`{"total_count":1500,"items":[{"id":1,"name":"api","owner":{"login":"acme"}}]}`))
+		})
+
+		result, total, err := newTestClient(server, "key").SearchRepositories(context.Background(), "created:>2020-01-01", 5000)
+
+		Expect(err).To(BeNil())
+		Expect(total).To(Equal(1500))
+		Expect(result.Repositories).To(HaveLen(1))
+		Expect(queries).To(Equal([]string{"created:>2020-01-01"}))
+	})
+})
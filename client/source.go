@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// Kinds of the repository sources.
+const (
+	SourceOrg     = "org"
+	SourceUser    = "user"
+	SourceMe      = "me"
+	SourceStarred = "starred"
+	SourceTeam    = "team"
+)
+
+var userPrefix = "user:"
+
+// ErrSourceNotFound is returned when the user, organization or team does not exist or is not visible to the token.
+var ErrSourceNotFound = errors.New("source not found")
+
+// Source is where the repositories are listed from. It is written as the organization name,
+// `user:<login>`, `me` for the repositories of the authenticated user, `starred` for the repositories
+// starred by the authenticated user or `<org>/<team-slug>` for the repositories of a team and its nested teams.
+type Source struct {
+	Kind string
+	// Owner is the organization or the user login, empty for me and starred.
+	Owner string
+	Team  string
+}
+
+// ParseSource parses the source, a name without a prefix is an organization.
+func ParseSource(s string) Source {
+	switch {
+	case s == SourceMe:
+		return Source{Kind: SourceMe}
+	case s == SourceStarred:
+		return Source{Kind: SourceStarred}
+	case strings.HasPrefix(s, userPrefix):
+		return Source{Kind: SourceUser, Owner: strings.TrimPrefix(s, userPrefix)}
+	case strings.Contains(s, "/"):
+		i := strings.Index(s, "/")
+		return Source{Kind: SourceTeam, Owner: s[:i], Team: s[i+1:]}
+	default:
+		return Source{Kind: SourceOrg, Owner: s}
+	}
+}
+
+func (s Source) String() string {
+	switch s.Kind {
+	case SourceMe, SourceStarred:
+		return s.Kind
+	case SourceUser:
+		return userPrefix + s.Owner
+	case SourceTeam:
+		return s.Owner + "/" + s.Team
+	default:
+		return s.Owner
+	}
+}
+
+// Organization returns the organization of the source, empty for the user sources.
+func (s Source) Organization() string {
+	if s.Kind == SourceOrg || s.Kind == SourceTeam {
+		return s.Owner
+	}
+
+	return ""
+}
+
+// ResolveSource checks the source on GitHub and returns it as spelled by GitHub,
+// or ErrSourceNotFound when it does not exist.
+func (ghc *githubclient) ResolveSource(ctx context.Context, source string) (string, error) {
+	src := ParseSource(source)
+
+	var err error
+
+	switch src.Kind {
+	case SourceOrg:
+		src.Owner, err = ghc.Organization(ctx, src.Owner)
+
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return "", ErrSourceNotFound
+		}
+	case SourceUser:
+		var user *github.User
+
+		err = ghc.do(ctx, func() (err error) {
+			user, _, err = ghc.client.Users.Get(ctx, src.Owner)
+			return err
+		})
+
+		src.Owner = user.GetLogin()
+	case SourceTeam:
+		var team *github.Team
+
+		err = ghc.do(ctx, func() (err error) {
+			team, _, err = ghc.client.Teams.GetTeamBySlug(ctx, src.Owner, src.Team)
+			return err
+		})
+
+		src.Team = team.GetSlug()
+
+		if login := team.GetOrganization().GetLogin(); login != "" {
+			src.Owner = login
+		}
+	}
+
+	if notFound(err) {
+		return "", ErrSourceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return src.String(), nil
+}
+
+// listRepositories lists every repository of the source.
+func (ghc *githubclient) listRepositories(ctx context.Context, src Source) ([]*github.Repository, error) {
+	switch src.Kind {
+	case SourceMe:
+		return paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return ghc.client.Repositories.List(ctx, "", &github.RepositoryListOptions{Affiliation: "owner", ListOptions: opt})
+		})
+	case SourceUser:
+		return paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return ghc.client.Repositories.List(ctx, src.Owner, &github.RepositoryListOptions{Type: "owner", ListOptions: opt})
+		})
+	case SourceStarred:
+		starred, err := paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.StarredRepository, *github.Response, error) {
+			return ghc.client.Activity.ListStarred(ctx, "", &github.ActivityListStarredOptions{ListOptions: opt})
+		})
+
+		repos := make([]*github.Repository, len(starred))
+
+		for i, s := range starred {
+			repos[i] = s.GetRepository()
+		}
+
+		return repos, err
+	case SourceTeam:
+		return ghc.teamRepositories(ctx, src.Owner, src.Team)
+	default:
+		return paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return ghc.client.Repositories.ListByOrg(ctx, src.Owner, &github.RepositoryListByOrgOptions{ListOptions: opt})
+		})
+	}
+}
+
+func notFound(err error) bool {
+	var errResp *github.ErrorResponse
+
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sources", func() {
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mux = http.NewServeMux()
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	DescribeTable("ParseSource",
+		func(s string, expected Source) {
+			Expect(ParseSource(s)).To(Equal(expected))
+			Expect(ParseSource(s).String()).To(Equal(s))
+		},
+		Entry("organization", "acme", Source{Kind: SourceOrg, Owner: "acme"}),
+		Entry("user", "user:octocat", Source{Kind: SourceUser, Owner: "octocat"}),
+		Entry("me", "me", Source{Kind: SourceMe}),
+		Entry("starred", "starred", Source{Kind: SourceStarred}),
+		Entry("team", "acme/backend", Source{Kind: SourceTeam, Owner: "acme", Team: "backend"}),
+	)
+
+	It("should follow every page of the organization repositories", func() {
+		mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"name":"web","owner":{"login":"acme"}}]`))
+				return
+			}
+
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?page=2>; rel="next"`, server.URL))
+			_, _ = w.Write([]byte(`[{"name":"api","owner":{"login":"acme"}}]`))
+		})
+
+		result, err := newTestClient(server, "key").Repositories(context.Background(), "acme")
+
+		Expect(err).To(BeNil())
+		Expect(result.RepositoryNames()).To(Equal([]string{"api - ", "web - "}))
+	})
+
+	It("should list the owned repositories of the authenticated user", func() {
+		mux.HandleFunc("/user/repos", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("affiliation")).To(Equal("owner"))
+			_, _ = w.Write([]byte(`[{"name":"dotfiles","language":"Shell","owner":{"login":"me"}}]`))
+		})
+
+		result, err := newTestClient(server, "key").Repositories(context.Background(), "me")
+
+		Expect(err).To(BeNil())
+		Expect(result.RepositoryNames()).To(Equal([]string{"dotfiles - Shell"}))
+	})
+
+	It("should list the repositories of the user", func() {
+		mux.HandleFunc("/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"name":"hello-world","owner":{"login":"octocat"}}]`))
+		})
+
+		result, err := newTestClient(server, "key").Repositories(context.Background(), "user:octocat")
+
+		Expect(err).To(BeNil())
+		Expect(result.Repositories[0].Name).To(Equal("hello-world"))
+	})
+
+	It("should prefix the starred repositories by their owner", func() {
+		mux.HandleFunc("/user/starred", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[
+				{"repo":{"name":"cli","language":"Go","owner":{"login":"acme"}}},
+				{"repo":{"name":"cli","language":"Rust","owner":{"login":"globex"}}}
+			]`))
+		})
+
+		result, err := newTestClient(server, "key").Repositories(context.Background(), "starred")
+
+		Expect(err).To(BeNil())
+		Expect(result.RepositoryNames()).To(Equal([]string{"acme/cli - Go", "globex/cli - Rust"}))
+		Expect(result.FindRepoByName("globex/cli - Rust").Owner).To(Equal("globex"))
+	})
+
+	It("should list the repositories of the team and its nested teams once", func() {
+		mux.HandleFunc("/orgs/acme/teams/backend/repos", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"id":1,"name":"api","owner":{"login":"acme"}}]`))
+		})
+		mux.HandleFunc("/orgs/acme/teams/backend/teams", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"slug":"payments"}]`))
+		})
+		mux.HandleFunc("/orgs/acme/teams/payments/repos", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"id":1,"name":"api","owner":{"login":"acme"}},{"id":2,"name":"billing","owner":{"login":"acme"}}]`))
+		})
+		mux.HandleFunc("/orgs/acme/teams/payments/teams", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[]`))
+		})
+
+		result, err := newTestClient(server, "key").Repositories(context.Background(), "acme/backend")
+
+		Expect(err).To(BeNil())
+		Expect(result.RepositoryNames()).To(Equal([]string{"api - ", "billing - "}))
+	})
+
+	It("should list the teams with their parent", func() {
+		mux.HandleFunc("/orgs/acme/teams", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"slug":"backend","name":"Backend"},{"slug":"payments","name":"Payments","parent":{"slug":"backend"}}]`))
+		})
+
+		teams, err := newTestClient(server, "key").Teams(context.Background(), "acme")
+
+		Expect(err).To(BeNil())
+		Expect(teams).To(Equal([]Team{
+			{Slug: "backend", Name: "Backend"},
+			{Slug: "payments", Name: "Payments", Parent: "backend"},
+		}))
+	})
+
+	Describe("ResolveSource", func() {
+		It("should return the user as spelled by GitHub", func() {
+			mux.HandleFunc("/users/OctoCat", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"login":"octocat"}`))
+			})
+
+			source, err := newTestClient(server, "key").ResolveSource(context.Background(), "user:OctoCat")
+
+			Expect(err).To(BeNil())
+			Expect(source).To(Equal("user:octocat"))
+		})
+
+		It("should report the missing team", func() {
+			mux.HandleFunc("/orgs/acme/teams/nope", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			})
+
+			_, err := newTestClient(server, "key").ResolveSource(context.Background(), "acme/nope")
+
+			Expect(err).To(MatchError(ErrSourceNotFound))
+		})
+
+		It("should accept me and starred without a request", func() {
+			source, err := newTestClient(server, "key").ResolveSource(context.Background(), "starred")
+
+			Expect(err).To(BeNil())
+			Expect(source).To(Equal("starred"))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// Team is a team of an organization, Parent is the slug of the parent team for nested teams.
+type Team struct {
+	Slug   string
+	Name   string
+	Parent string
+}
+
+// Teams returns the teams of the organization visible to the token.
+func (ghc *githubclient) Teams(ctx context.Context, org string) ([]Team, error) {
+	teams, err := paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Team, *github.Response, error) {
+		return ghc.client.Teams.ListTeams(ctx, org, &opt)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Team, len(teams))
+
+	for i, t := range teams {
+		result[i] = Team{
+			Slug:   t.GetSlug(),
+			Name:   t.GetName(),
+			Parent: t.GetParent().GetSlug(),
+		}
+	}
+
+	return result, nil
+}
+
+// teamRepositories returns the repositories of the team and of its nested child teams,
+// a repository shared by several teams is returned once.
+func (ghc *githubclient) teamRepositories(ctx context.Context, org, slug string) ([]*github.Repository, error) {
+	var repos []*github.Repository
+
+	seen := map[int64]bool{}
+	visited := map[string]bool{slug: true}
+	queue := []string{slug}
+
+	for len(queue) > 0 {
+		team := queue[0]
+		queue = queue[1:]
+
+		teamRepos, err := paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return ghc.client.Teams.ListTeamReposBySlug(ctx, org, team, &opt)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range teamRepos {
+			if !seen[repo.GetID()] {
+				seen[repo.GetID()] = true
+				repos = append(repos, repo)
+			}
+		}
+
+		children, err := paginate(ctx, ghc, func(opt github.ListOptions) ([]*github.Team, *github.Response, error) {
+			return ghc.client.Teams.ListChildTeamsByParentSlug(ctx, org, team, &opt)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			if !visited[child.GetSlug()] {
+				visited[child.GetSlug()] = true
+				queue = append(queue, child.GetSlug())
+			}
+		}
+	}
+
+	return repos, nil
+}
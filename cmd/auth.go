@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/config"
+
+	"github.com/spf13/cobra"
+)
+
+var authOrg string
+var authHost string
+var authClientID string
+
+var requiredScopes = []string{"repo", "read:org"}
+
+func init() {
+	authStatusCmd.Flags().StringVarP(&authOrg, "org", "o", "", "organization to check the SSO authorization, default organization when empty")
+
+	authLoginCmd.Flags().StringVar(&authHost, "host", "", "GitHub host of a new profile, github.com when empty")
+	authLoginCmd.Flags().StringVar(&authClientID, "client-id", "", "client id of the OAuth app with the device flow enabled, overrides client_id")
+
+	authCmd.AddCommand(authStatusCmd, authLoginCmd, authRateLimitCmd)
+	RootCmd.AddCommand(authCmd)
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect the GitHub authentication",
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the authenticated user, token type, expiry and scopes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		org := authOrg
+
+		if org == "" {
+			org = conf.DefaultOrganization
+		}
+
+		name := profile
+
+		if name == "" {
+			name = conf.ProfileFor(org)
+		}
+
+		p, err := conf.Profile(name)
+
+		if err != nil {
+			return err
+		}
+
+		ghc, err := clientFor(org)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Profile: %s \n", name)
+
+		return tokenStatus(cmd.Context(), ghc, hostName(p.Host), org)
+	},
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:         "login",
+	Short:       "Authenticate in the browser with the OAuth device flow and store the token",
+	Example:     "orc auth login --profile work --host github.example.com",
+	Annotations: map[string]string{skipSetup: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := profile
+
+		if name == "" {
+			name = config.DefaultProfile
+		}
+
+		host := authHost
+
+		if p, err := conf.Profile(name); err == nil && p.Host != "" {
+			host = p.Host
+		}
+
+		clientID := authClientID
+
+		if clientID == "" {
+			clientID = conf.ClientID
+		}
+
+		if clientID == "" {
+			return fmt.Errorf("OAuth app client id is missing, set it with --client-id, %s or client_id in the config", config.EnvClientID)
+		}
+
+		token, err := deviceLogin(cmd.Context(), client.NewDeviceFlow(clientID, host, requiredScopes))
+
+		if err != nil {
+			return err
+		}
+
+		ghc, err := client.NewGithubClientForHost(token, host)
+
+		if err != nil {
+			return err
+		}
+
+		if err = tokenStatus(cmd.Context(), ghc, hostName(host), ""); err != nil {
+			return err
+		}
+
+		return saveKey(name, host, token)
+	},
+}
+
+var authRateLimitCmd = &cobra.Command{
+	Use:   "rate-limit",
+	Short: "Show the remaining API quota of the profile",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ghc, err := clientFor(conf.DefaultOrganization)
+
+		if err != nil {
+			return err
+		}
+
+		limits, err := ghc.RateLimits(cmd.Context())
+
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(w, "RESOURCE\tREMAINING\tLIMIT\tRESET")
+
+		for _, l := range limits {
+			reset := time.Until(l.Reset).Round(time.Second)
+
+			if reset < 0 {
+				reset = 0
+			}
+
+			fmt.Fprintf(w, "%s\t%d\t%d\tin %s (%s)\n", l.Resource, l.Remaining, l.Limit, reset, l.Reset.Format(time.Kitchen))
+		}
+
+		return w.Flush()
+	},
+}
+
+// deviceLogin shows the user code and waits until the user authorizes the device in the browser.
+func deviceLogin(ctx context.Context, flow *client.DeviceFlow) (string, error) {
+	code, err := flow.RequestCode(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Open %s and enter the code: %s \n", code.VerificationURI, code.UserCode)
+
+	s.Prefix = "Waiting for the authorization "
+	s.Start()
+	token, err := flow.PollToken(ctx, code)
+	s.Stop()
+
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// saveKey stores the token in the profile, the configuration file is created when it does not exist.
+func saveKey(name, host, key string) error {
+	if !confService.CheckConfigFile() {
+		org := readOrganization()
+
+		if _, err := confService.Create("", org); err != nil {
+			return err
+		}
+
+		fmt.Printf("Config file created to here: %s \n", confService.ConfigFile())
+	}
+
+	_, exists := conf.Profiles[name]
+
+	var err error
+
+	if exists || name == config.DefaultProfile {
+		err = confService.UpdateKey(name, key)
+	} else {
+		err = confService.AddProfile(name, config.Profile{APIKey: key, Host: host})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Token stored in the profile %s \n", name)
+
+	return nil
+}
+
+// tokenStatus prints the token details and warns about the missing scopes and SSO authorization.
+func tokenStatus(ctx context.Context, ghc client.IGithubClient, host, org string) error {
+	info, err := ghc.Authenticated(ctx)
+
+	if err != nil {
+		return fmt.Errorf("token is not valid for %s: %w", host, err)
+	}
+
+	fmt.Printf("Logged in to %s as %s \n", host, info.Login)
+	fmt.Printf("Token type: %s \n", info.Type)
+
+	if info.ExpiresAt.IsZero() {
+		fmt.Println("Token expiry: never")
+	} else {
+		fmt.Printf("Token expiry: %s \n", info.ExpiresAt.Format(time.RFC1123))
+	}
+
+	if info.ScopesKnown {
+		fmt.Printf("Token scopes: %s \n", strings.Join(info.Scopes, ", "))
+	} else {
+		fmt.Println("Token scopes: not reported for this token type")
+	}
+
+	if missing := info.MissingScopes(requiredScopes...); len(missing) > 0 {
+		fmt.Printf("Warning: token is missing the scopes: %s \n", strings.Join(missing, ", "))
+	}
+
+	if org == "" {
+		return nil
+	}
+
+	url, err := ghc.SSOAuthorization(ctx, org)
+
+	if err != nil {
+		fmt.Printf("Warning: could not check the organization %s: %v \n", org, err)
+		return nil
+	}
+
+	if url != "" {
+		fmt.Printf("Warning: token is not authorized for the SAML SSO of %s, authorize it here: %s \n", org, url)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Aykutfgoktas/orc/client"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var cloneTeam string
+var pickTeam bool
+
+func init() {
+	cloneCmd.Flags().StringVarP(&cloneTeam, "team", "t", "", "list the repositories of the team with the given slug and of its nested teams")
+	cloneCmd.Flags().BoolVar(&pickTeam, "pick-team", false, "select the team from the teams of the organization")
+
+	cloneCmd.ValidArgsFunction = completeOrganizations
+
+	RootCmd.AddCommand(cloneCmd)
+}
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone [organization]",
+	Short: "Select repositories of the organization or the team and clone them",
+	Example: "orc clone\n" +
+		"orc clone my-company --team platform\n" +
+		"orc clone --pick-team",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := conf.DefaultOrganization
+
+		if len(args) == 1 {
+			source = args[0]
+		}
+
+		if cloneTeam != "" || pickTeam {
+			team, err := teamSource(cmd.Context(), source)
+
+			if err != nil {
+				return err
+			}
+
+			source = team
+		}
+
+		repos, err := fetchRepositories(cmd.Context(), source)
+
+		if err != nil {
+			return err
+		}
+
+		selected, err := selectRepositories(repos)
+
+		if err != nil {
+			return err
+		}
+
+		return cloneRepositories(cmd.Context(), selected)
+	},
+}
+
+// teamSource returns the team source of the organization, the team is asked when it is not given.
+func teamSource(ctx context.Context, source string) (string, error) {
+	org := client.ParseSource(source).Organization()
+
+	if org == "" {
+		return "", fmt.Errorf("teams belong to an organization, %s is not an organization", source)
+	}
+
+	team := cloneTeam
+
+	if pickTeam {
+		selected, err := selectTeam(ctx, org)
+
+		if err != nil {
+			return "", err
+		}
+
+		team = selected
+	}
+
+	return client.Source{Kind: client.SourceTeam, Owner: org, Team: team}.String(), nil
+}
+
+// selectTeam asks the team among the teams of the organization, nested teams show their parent.
+func selectTeam(ctx context.Context, org string) (string, error) {
+	ghc, err := clientFor(org)
+
+	if err != nil {
+		return "", err
+	}
+
+	s.Prefix = "Getting the list of teams from " + org + " "
+	s.Start()
+	teams, err := ghc.Teams(ctx, org)
+	s.Stop()
+
+	if err != nil {
+		return "", fmt.Errorf("getting the teams from %s: %w", org, err)
+	}
+
+	if len(teams) == 0 {
+		return "", fmt.Errorf("there is no team in %s visible to the token", org)
+	}
+
+	options := make([]string, len(teams))
+	slugs := map[string]string{}
+
+	for i, t := range teams {
+		options[i] = t.Slug
+
+		if t.Parent != "" {
+			options[i] = t.Parent + " > " + t.Slug
+		}
+
+		slugs[options[i]] = t.Slug
+	}
+
+	var selected string
+
+	prompt := &survey.Select{
+		Message: "Select a team:",
+		Options: options,
+	}
+
+	if err := survey.AskOne(prompt, &selected, survey.WithPageSize(pageSize)); err != nil {
+		return "", err
+	}
+
+	return slugs[selected], nil
+}
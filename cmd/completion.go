@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/config"
+
+	"github.com/spf13/cobra"
+)
+
+// repoCache keeps the repository names of every listed source for the shell completions,
+// completing from the GitHub API would be too slow to type against.
+var repoCache = cfile.New(cfile.CacheFile("repositories.json"))
+
+// cacheRepositories replaces the cached names of the source, the cache is best effort and errors are ignored.
+func cacheRepositories(source string, repos *client.RepositoriesResult) {
+	unlock, err := repoCache.Lock()
+
+	if err != nil {
+		return
+	}
+
+	defer unlock() //nolint:errcheck
+
+	cache := readRepoCache()
+
+	names := make([]string, 0, len(repos.Repositories))
+
+	for _, r := range repos.Repositories {
+		names = append(names, r.Owner+"/"+r.Name)
+	}
+
+	cache[source] = names
+
+	repoCache.Writer(cache) //nolint:errcheck
+}
+
+func readRepoCache() map[string][]string {
+	cache := map[string][]string{}
+
+	if !repoCache.CheckConfigFile() {
+		return cache
+	}
+
+	if result, err := repoCache.Reader(); err == nil {
+		result.Decode(&cache) //nolint:errcheck
+	}
+
+	return cache
+}
+
+// cachedRepositories returns the cached owner/name of every source without duplicates.
+func cachedRepositories() []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, repos := range readRepoCache() {
+		for _, name := range repos {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// completionConfig reads the configuration for the completions, the hooks loading it
+// do not run while completing and the completions must never prompt for the setup.
+func completionConfig() config.Config {
+	system, user, project := configFiles()
+
+	if !user.CheckConfigFile() {
+		user = nil
+	}
+
+	c, err := config.NewLayered(system, user, project).Read()
+
+	if err != nil {
+		return config.Config{}
+	}
+
+	return *c
+}
+
+// completeOrganizations completes the first argument with the configured organizations.
+func completeOrganizations(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completionConfig().Organizations, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c := completionConfig()
+
+	return c.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBinding completes the organization and then the profile of profile bind.
+func completeBinding(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeOrganizations(cmd, args, toComplete)
+	case 1:
+		return completeProfiles(cmd, args, toComplete)
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeClones completes the cloned repositories from the history and the cached repository names.
+func completeClones(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seen := map[string]bool{}
+	names := []string{}
+
+	add := func(name string) {
+		if !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, e := range recentClones() {
+		add(e.FullName())
+	}
+
+	for _, name := range cachedRepositories() {
+		add(name)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
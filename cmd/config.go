@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var showOrigin bool
+
+var visibleKeyChars = 4
+
+func init() {
+	configShowCmd.Flags().BoolVar(&showOrigin, "origin", false, "show where each value comes from")
+
+	configCmd.AddCommand(configShowCmd)
+	RootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the merged configuration of the system, user and project files and the environment",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, err := confService.Show()
+
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, v := range values {
+			value := v.Value
+
+			if v.Key == "key" || strings.HasSuffix(v.Key, ".key") {
+				value = maskKey(value)
+			}
+
+			if showOrigin {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", v.Key, value, v.Origin)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", v.Key, value)
+			}
+		}
+
+		return w.Flush()
+	},
+}
+
+// maskKey hides the API key except the last few characters.
+func maskKey(key string) string {
+	if len(key) <= visibleKeyChars {
+		return strings.Repeat("*", len(key))
+	}
+
+	return strings.Repeat("*", len(key)-visibleKeyChars) + key[len(key)-visibleKeyChars:]
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/spf13/cobra"
+)
+
+var execOrg string
+var execLanguage string
+var execTopic string
+
+// execResult is the exit code of the command in a repository.
+type execResult struct {
+	repo workspace.Repo
+	code int
+	err  error
+}
+
+func init() {
+	execCmd.Flags().StringVar(&execOrg, "org", "", "organization or other source of the repositories, the default organization when empty")
+	execCmd.Flags().StringVar(&execLanguage, "language", "", "run only in the repositories with the language")
+	execCmd.Flags().StringVar(&execTopic, "topic", "", "run only in the repositories with the topic")
+	execCmd.Flags().StringVarP(&workspacePath, "workspace", "w", "", "directory of the cloned repositories, the configured workspace or the current directory when empty")
+	execCmd.Flags().IntVarP(&jobs, "jobs", "j", jobs, "number of repositories the command runs in at the same time")
+
+	_ = execCmd.RegisterFlagCompletionFunc("org", completeOrganizations)
+
+	RootCmd.AddCommand(execCmd)
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec -- <command> [args...]",
+	Short: "Run the command in every cloned repository of the organization",
+	Long: "Run the command in every cloned repository of the organization.\n\n" +
+		"A single argument is run by the shell, so commands can be chained in quotes.",
+	Example: "orc exec --org my-company -- 'git checkout main && git pull'\n" +
+		"orc exec --org my-company --language go -j 4 -- go test ./...",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := execOrg
+
+		if source == "" {
+			source = conf.DefaultOrganization
+		}
+
+		repos, err := execRepos(cmd.Context(), source)
+
+		if err != nil {
+			return err
+		}
+
+		if len(repos) == 0 {
+			fmt.Printf("No cloned repository of %s in the workspace \n", source)
+			return nil
+		}
+
+		results := execAll(cmd.Context(), repos, args)
+
+		if err := cmd.Context().Err(); err != nil {
+			return err
+		}
+
+		return printExecSummary(results)
+	},
+}
+
+// execRepos returns the cloned repositories of the source, the repositories are checked on GitHub
+// when the source is not an organization or when they are filtered by language or topic.
+func execRepos(ctx context.Context, source string) ([]workspace.Repo, error) {
+	cloned, err := scanWorkspace(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	src := client.ParseSource(source)
+
+	if src.Kind == client.SourceOrg && execLanguage == "" && execTopic == "" {
+		var repos []workspace.Repo
+
+		for _, repo := range cloned {
+			if strings.EqualFold(repo.Owner, src.Owner) {
+				repos = append(repos, repo)
+			}
+		}
+
+		return repos, nil
+	}
+
+	listed, err := fetchRepositories(ctx, source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matching := map[string]bool{}
+
+	for _, r := range listed.Repositories {
+		if matchesFilters(r) {
+			matching[strings.ToLower(r.Owner+"/"+r.Name)] = true
+		}
+	}
+
+	var repos []workspace.Repo
+
+	for _, repo := range cloned {
+		if matching[strings.ToLower(repo.FullName())] {
+			repos = append(repos, repo)
+		}
+	}
+
+	return repos, nil
+}
+
+func matchesFilters(r client.Repository) bool {
+	if execLanguage != "" && !strings.EqualFold(r.Language, execLanguage) {
+		return false
+	}
+
+	if execTopic == "" {
+		return true
+	}
+
+	for _, topic := range r.Topics {
+		if strings.EqualFold(topic, execTopic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// execAll runs the command in the repositories in parallel, the output lines are prefixed by the repository.
+func execAll(ctx context.Context, repos []workspace.Repo, args []string) []execResult {
+	width := 0
+
+	for _, repo := range repos {
+		if len(repo.FullName()) > width {
+			width = len(repo.FullName())
+		}
+	}
+
+	var mu sync.Mutex
+
+	results := make([]execResult, len(repos))
+
+	workspace.ForEach(repos, jobs, func(i int, repo workspace.Repo) {
+		prefix := fmt.Sprintf("%-*s | ", width, repo.FullName())
+		stdout := workspace.NewPrefixWriter(os.Stdout, &mu, prefix)
+		stderr := workspace.NewPrefixWriter(os.Stderr, &mu, prefix)
+
+		code, err := repo.Run(ctx, args, stdout, stderr)
+
+		_ = stdout.Flush()
+		_ = stderr.Flush()
+
+		results[i] = execResult{repo: repo, code: code, err: err}
+	})
+
+	return results
+}
+
+func printExecSummary(results []execResult) error {
+	var failed []execResult
+
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, r)
+		}
+	}
+
+	fmt.Printf("\nSucceeded in %d of %d repositories \n", len(results)-len(failed), len(results))
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "FAILED\tEXIT CODE\tERROR")
+
+	for _, r := range failed {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", r.repo.FullName(), r.code, firstLine(r.err.Error()))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("the command failed in %d of %d repositories", len(failed), len(results))
+}
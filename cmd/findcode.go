@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/utils"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var codeOrg string
+
+func init() {
+	findCodeCmd.Flags().StringVar(&codeOrg, "org", "", "organization or user:<login> to search in, the default organization when empty")
+
+	_ = findCodeCmd.RegisterFlagCompletionFunc("org", completeOrganizations)
+
+	RootCmd.AddCommand(findCodeCmd)
+}
+
+var findCodeCmd = &cobra.Command{
+	Use:   "find-code <text>",
+	Short: "Find the repositories containing the code and clone or open the selected ones",
+	Long: "Find the repositories containing the code and clone or open the selected ones.\n\n" +
+		"The text accepts the qualifiers of the code search like path:, extension: and language:.",
+	Example: "orc find-code KAFKA_BROKERS --org my-company\n" +
+		"orc find-code 'NewClient language:go'",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := codeOrg
+
+		if source == "" {
+			source = conf.DefaultOrganization
+		}
+
+		qualifier, err := ownerQualifier(source)
+
+		if err != nil {
+			return err
+		}
+
+		ghc, err := clientFor(source)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = "Searching the code in " + source + " "
+		s.Start()
+		matches, total, err := ghc.SearchCode(cmd.Context(), args[0]+" "+qualifier)
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("searching the code: %w", err)
+		}
+
+		if len(matches) == 0 {
+			fmt.Printf("No code found in %s \n", source)
+			return nil
+		}
+
+		printCodeMatches(matches, total)
+
+		repos, err := selectCodeRepositories(matches)
+
+		if err != nil {
+			return err
+		}
+
+		var action string
+
+		prompt := &survey.Select{
+			Message: "Select the action:",
+			Options: []string{actionClone, actionOpen},
+		}
+
+		if err := survey.AskOne(prompt, &action); err != nil {
+			return err
+		}
+
+		if action == actionClone {
+			return cloneRepositories(cmd.Context(), repos)
+		}
+
+		for _, repo := range repos {
+			if err := utils.OpenBrowser(repo.URL); err != nil {
+				return fmt.Errorf("opening %s: %w", repo.URL, err)
+			}
+		}
+
+		return nil
+	},
+}
+
+// ownerQualifier returns the qualifier limiting the code and issue searches to the source,
+// these searches are only possible in organizations and users.
+func ownerQualifier(source string) (string, error) {
+	src := client.ParseSource(source)
+
+	switch src.Kind {
+	case client.SourceOrg:
+		return "org:" + src.Owner, nil
+	case client.SourceUser:
+		return "user:" + src.Owner, nil
+	default:
+		return "", fmt.Errorf("searching is only possible in organizations and users, not in %s", source)
+	}
+}
+
+func printCodeMatches(matches []client.CodeMatches, total int) {
+	files := 0
+
+	for _, m := range matches {
+		fmt.Println(m.Repository.Owner + "/" + m.Repository.Name)
+
+		for _, f := range m.Files {
+			fmt.Println("  " + f.Path)
+
+			for _, fragment := range f.Fragments {
+				for _, line := range strings.Split(fragment, "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						fmt.Println("      " + line)
+					}
+				}
+			}
+		}
+
+		files += len(m.Files)
+	}
+
+	if total > files {
+		fmt.Printf("Showing %d of %d files, narrow the text to see the rest \n", files, total)
+	}
+}
+
+// selectCodeRepositories asks the repositories among the ones with matching files.
+func selectCodeRepositories(matches []client.CodeMatches) ([]client.Repository, error) {
+	options := make([]string, len(matches))
+	repos := map[string]client.Repository{}
+
+	for i, m := range matches {
+		options[i] = fmt.Sprintf("%s/%s (%d files)", m.Repository.Owner, m.Repository.Name, len(m.Files))
+		repos[options[i]] = m.Repository
+	}
+
+	var selected []string
+
+	prompt := &survey.MultiSelect{
+		Message: "Select repositories:",
+		Options: options,
+	}
+
+	if err := survey.AskOne(prompt, &selected, survey.WithPageSize(pageSize)); err != nil {
+		return nil, err
+	}
+
+	result := make([]client.Repository, len(selected))
+
+	for i, option := range selected {
+		result[i] = repos[option]
+	}
+
+	return result, nil
+}
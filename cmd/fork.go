@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/config"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/spf13/cobra"
+)
+
+var forkOrg string
+
+// upstreamRemote is the remote of the forks pointing at the forked repository.
+var upstreamRemote = "upstream"
+
+func init() {
+	forkCmd.Flags().StringVar(&forkOrg, "org", "", "organization to create the fork in instead of your account")
+
+	_ = forkCmd.RegisterFlagCompletionFunc("org", completeOrganizations)
+
+	RootCmd.AddCommand(forkCmd)
+}
+
+var forkCmd = &cobra.Command{
+	Use:   "fork <repository>",
+	Short: "Fork the repository, clone the fork and add the original as the upstream remote",
+	Long: "Fork the repository into your account or the given organization, clone the fork into the current directory " +
+		"and add the original repository as the upstream remote.\n\n" +
+		"The repository is given as org/repo, or as repo in the default organization. " +
+		"An existing fork is cloned as it is.",
+	Example: "orc fork kubernetes/kubectl\n" +
+		"orc fork kubernetes/kubectl --org my-company",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, name, err := parseRepository(args[0])
+
+		if err != nil {
+			return err
+		}
+
+		ghc, err := clientFor(owner)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = "Getting the repository " + owner + "/" + name + " "
+		s.Start()
+		upstream, err := ghc.Repository(cmd.Context(), owner, name)
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("getting the repository %s/%s: %w", owner, name, err)
+		}
+
+		forker, err := forkClient(owner, ghc)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = "Forking " + owner + "/" + name + " and waiting for the fork "
+		s.Start()
+		fork, err := forker.Fork(cmd.Context(), upstream.Owner, upstream.Name, forkOrg)
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("forking %s/%s: %w", owner, name, err)
+		}
+
+		fmt.Printf("Repository forked to %s/%s \n", fork.Owner, fork.Name)
+
+		repo := workspace.Open(cmd.Context(), fork.Dir())
+
+		// The fork is cloned already when the command is run again.
+		if !strings.EqualFold(repo.FullName(), fork.Owner+"/"+fork.Name) {
+			if err := cloneRepository(cmd.Context(), *fork); err != nil {
+				return err
+			}
+
+			repo = workspace.Open(cmd.Context(), fork.Dir())
+		}
+
+		if err := repo.EnsureRemote(cmd.Context(), upstreamRemote, upstream.SSHUrl); err != nil {
+			return fmt.Errorf("adding the %s remote: %w", upstreamRemote, err)
+		}
+
+		s.Prefix = "Fetching " + upstreamRemote + " "
+		s.Start()
+		_, err = workspace.Git(cmd.Context(), repo.Path, "fetch", "--quiet", upstreamRemote)
+		s.Stop()
+
+		if err != nil {
+			fmt.Printf("Error while fetching the %s remote: %v \n", upstreamRemote, err)
+		}
+
+		changeDir(repo.Path)
+
+		return nil
+	},
+}
+
+// forkClient returns the client creating the fork: the client of the organization given with --org, or
+// the client the upstream was read with when forking into the account. A GitHub App installation has no
+// account to fork into, the default profile creates the fork instead.
+func forkClient(owner string, upstream client.IGithubClient) (client.IGithubClient, error) {
+	if forkOrg != "" {
+		return clientFor(forkOrg)
+	}
+
+	p, err := conf.Profile(profileName(owner))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.IsApp() {
+		return upstream, nil
+	}
+
+	return profileClient(config.DefaultProfile)
+}
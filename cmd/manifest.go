@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/spf13/cobra"
+)
+
+var defaultManifest = "orc.manifest.yaml"
+
+var manifestSelect bool
+var manifestOrg string
+var manifestLayout string
+var manifestOptions client.CloneOptions
+
+func init() {
+	manifestExportCmd.Flags().BoolVar(&manifestSelect, "select", false, "select the repositories of the organization instead of exporting the workspace")
+	manifestExportCmd.Flags().StringVar(&manifestOrg, "org", "", "organization to select the repositories from, the default organization when empty")
+	manifestExportCmd.Flags().StringVar(&manifestLayout, "layout", workspace.LayoutFlat, "layout of the selected repositories, flat or org")
+	manifestExportCmd.Flags().IntVar(&manifestOptions.Depth, "depth", 0, "clone the selected repositories with the given history depth")
+	manifestExportCmd.Flags().BoolVar(&manifestOptions.SingleBranch, "single-branch", false, "clone only one branch of the selected repositories")
+	manifestExportCmd.Flags().StringVarP(&workspacePath, "workspace", "w", "", "directory of the cloned repositories, the configured workspace or the current directory when empty")
+	manifestExportCmd.Flags().BoolVar(&allRepos, "all", false, "export the repositories of every owner, not only the configured organizations")
+
+	manifestApplyCmd.Flags().StringVarP(&workspacePath, "workspace", "w", "", "directory of the cloned repositories, the configured workspace or the current directory when empty")
+	manifestApplyCmd.Flags().IntVarP(&jobs, "jobs", "j", jobs, "number of repositories cloned or updated at the same time")
+
+	_ = manifestExportCmd.RegisterFlagCompletionFunc("org", completeOrganizations)
+
+	manifestCmd.AddCommand(manifestExportCmd, manifestApplyCmd)
+	RootCmd.AddCommand(manifestCmd)
+}
+
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Share the repositories of a workspace as a YAML or JSON manifest file",
+}
+
+var manifestExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the repositories of the workspace or the selected ones to the manifest file",
+	Example: "orc manifest export backend.yaml\n" +
+		"orc manifest export backend.json --select --org my-company --layout org --depth 1",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := defaultManifest
+
+		if len(args) == 1 {
+			file = args[0]
+		}
+
+		var m *workspace.Manifest
+		var err error
+
+		if manifestSelect {
+			m, err = selectedManifest(cmd.Context())
+		} else {
+			m, err = workspaceManifest(cmd.Context())
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.Validate(); err != nil {
+			return err
+		}
+
+		if _, err = cfile.New(file).Writer(m); err != nil {
+			return fmt.Errorf("writing the manifest: %w", err)
+		}
+
+		fmt.Printf("Manifest with %d repositories written to %s \n", len(m.Repositories), file)
+
+		return nil
+	},
+}
+
+var manifestApplyCmd = &cobra.Command{
+	Use:     "apply <file>",
+	Short:   "Clone the repositories of the manifest into the workspace and update the cloned ones",
+	Example: "orc manifest apply backend.yaml --workspace ~/src",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := readManifest(args[0])
+
+		if err != nil {
+			return err
+		}
+
+		root, err := workspaceDir()
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = fmt.Sprintf("Applying %d repositories ", len(m.Repositories))
+		s.Start()
+		results := applyManifest(cmd.Context(), m, root)
+		s.Stop()
+
+		return printResults(m, results)
+	},
+}
+
+// selectedManifest returns the manifest of the repositories selected from the organization.
+func selectedManifest(ctx context.Context) (*workspace.Manifest, error) {
+	source := manifestOrg
+
+	if source == "" {
+		source = conf.DefaultOrganization
+	}
+
+	repos, err := fetchRepositories(ctx, source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := selectRepositories(repos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &workspace.Manifest{Layout: manifestLayout}
+
+	for _, repo := range selected {
+		m.Repositories = append(m.Repositories, workspace.ManifestEntry{
+			Org:          repo.Owner,
+			Repo:         repo.Name,
+			Depth:        manifestOptions.Depth,
+			SingleBranch: manifestOptions.SingleBranch,
+		})
+	}
+
+	return m, nil
+}
+
+// workspaceManifest returns the manifest of the cloned repositories with their current branch and path.
+func workspaceManifest(ctx context.Context) (*workspace.Manifest, error) {
+	root, err := workspaceDir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := workspaceRepos(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &workspace.Manifest{}
+
+	for _, repo := range repos {
+		if repo.Owner == "" {
+			fmt.Printf("Skipping %s, its origin remote is not a GitHub repository \n", repo.Path)
+			continue
+		}
+
+		st, err := repo.Status(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("reading the status of %s: %w", repo.FullName(), err)
+		}
+
+		path, err := filepath.Rel(root, repo.Path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		m.Repositories = append(m.Repositories, workspace.ManifestEntry{
+			Org:    repo.Owner,
+			Repo:   repo.Name,
+			Branch: st.Branch,
+			Path:   path,
+		})
+	}
+
+	return m, nil
+}
+
+func readManifest(file string) (*workspace.Manifest, error) {
+	f := cfile.New(file)
+
+	if !f.CheckConfigFile() {
+		return nil, fmt.Errorf("manifest %s does not exist", file)
+	}
+
+	r, err := f.Reader()
+
+	if err != nil {
+		return nil, fmt.Errorf("reading the manifest: %w", err)
+	}
+
+	m := &workspace.Manifest{}
+
+	if err = r.Decode(m); err != nil {
+		return nil, fmt.Errorf("decoding the manifest: %w", err)
+	}
+
+	return m, m.Validate()
+}
+
+// applyResult is what happened to a repository of the manifest, err is set when it failed.
+type applyResult struct {
+	action string
+	err    error
+}
+
+// applyManifest clones the missing repositories and updates the cloned ones in parallel,
+// it returns the result of every repository of the manifest.
+func applyManifest(ctx context.Context, m *workspace.Manifest, root string) []applyResult {
+	results := make([]applyResult, len(m.Repositories))
+	owners := make([]string, len(m.Repositories))
+
+	for i, e := range m.Repositories {
+		owners[i] = e.Org
+	}
+
+	ghcs, errs := ownerClients(owners)
+
+	workspace.ForEach(m.Repositories, jobs, func(i int, e workspace.ManifestEntry) {
+		dir := m.Dir(root, e)
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			err := workspace.Open(ctx, dir).Update(ctx, e.Branch)
+
+			switch {
+			case errors.Is(err, workspace.ErrDirty):
+				results[i] = applyResult{action: "fetched, not updated because of local changes"}
+			case err != nil:
+				results[i] = applyResult{err: err}
+			default:
+				results[i] = applyResult{action: "updated"}
+			}
+
+			return
+		}
+
+		err := cloneEntry(ctx, ghcs[e.Org], errs[e.Org], e, dir)
+		results[i] = applyResult{action: "cloned", err: err}
+	})
+
+	return results
+}
+
+func cloneEntry(ctx context.Context, ghc client.IGithubClient, clientErr error, e workspace.ManifestEntry, dir string) error {
+	if clientErr != nil {
+		return clientErr
+	}
+
+	repo, err := ghc.Repository(ctx, e.Org, e.Repo)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+
+	err = repo.CloneTo(ctx, client.CloneOptions{
+		Dir:          dir,
+		Branch:       e.Branch,
+		Depth:        e.Depth,
+		SingleBranch: e.SingleBranch,
+	})
+
+	if err == nil {
+		recordClone(*repo, dir)
+	}
+
+	return err
+}
+
+func printResults(m *workspace.Manifest, results []applyResult) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "REPOSITORY\tRESULT")
+
+	failed := 0
+
+	for i, e := range m.Repositories {
+		if results[i].err != nil {
+			fmt.Fprintf(w, "%s/%s\terror: %s\n", e.Org, e.Repo, firstLine(results[i].err.Error()))
+			failed++
+		} else {
+			fmt.Fprintf(w, "%s/%s\t%s\n", e.Org, e.Repo, results[i].action)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, len(m.Repositories))
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var openPulls bool
+var openIssues bool
+var openActions bool
+
+func init() {
+	openCmd.Flags().BoolVar(&openPulls, "prs", false, "open the pull requests")
+	openCmd.Flags().BoolVar(&openIssues, "issues", false, "open the issues")
+	openCmd.Flags().BoolVar(&openActions, "actions", false, "open the workflow runs")
+
+	openCmd.MarkFlagsMutuallyExclusive("prs", "issues", "actions")
+
+	RootCmd.AddCommand(openCmd)
+}
+
+var openCmd = &cobra.Command{
+	Use:   "open <repository>",
+	Short: "Open the repository page in the browser",
+	Long: "Open the repository page in the browser.\n\n" +
+		"The repository is given as org/repo, or as repo in the default organization. " +
+		"The browser is the command in $BROWSER, or the default browser of the system.",
+	Example: "orc open my-company/api\n" +
+		"orc open api --prs",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, name, err := parseRepository(args[0])
+
+		if err != nil {
+			return err
+		}
+
+		ghc, err := clientFor(owner)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = "Getting the repository " + owner + "/" + name + " "
+		s.Start()
+		repo, err := ghc.Repository(cmd.Context(), owner, name)
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("getting the repository %s/%s: %w", owner, name, err)
+		}
+
+		url := repo.URL
+
+		switch {
+		case openPulls:
+			url += "/pulls"
+		case openIssues:
+			url += "/issues"
+		case openActions:
+			url += "/actions"
+		}
+
+		if err := utils.OpenBrowser(url); err != nil {
+			return fmt.Errorf("opening %s: %w", url, err)
+		}
+
+		return nil
+	},
+}
+
+// splitRepository splits org/repo, the repository without the organization is in the default organization.
+func splitRepository(arg string) (owner, name string) {
+	if owner, name, ok := strings.Cut(arg, "/"); ok {
+		return owner, name
+	}
+
+	return conf.DefaultOrganization, arg
+}
+
+// parseRepository splits the repository argument and rejects the ones not in the org/repo form.
+func parseRepository(arg string) (owner, name string, err error) {
+	owner, name = splitRepository(arg)
+
+	if owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("repository %q is not in the org/repo form", arg)
+	}
+
+	return owner, name, nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/client"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+// addedMark marks the organizations that are already in the configuration.
+var addedMark = " (added)"
+
+func init() {
+	orgCmd.AddCommand(orgAddCmd)
+	RootCmd.AddCommand(orgCmd)
+}
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage the organizations",
+}
+
+var orgAddCmd = &cobra.Command{
+	Use:   "add [organization...]",
+	Short: "Add organizations, without arguments select them from the organizations of the token",
+	Long: "Add organizations, without arguments select them from the organizations of the token.\n\n" +
+		"Besides organizations, user:<login> lists the repositories of the user, me the repositories of the\n" +
+		"authenticated user, starred the repositories starred by the authenticated user and <org>/<team-slug>\n" +
+		"the repositories of the team.",
+	Example: "orc org add\n" +
+		"orc org add my-company user:octocat starred my-company/backend",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		orgs := args
+
+		if len(orgs) == 0 {
+			selected, err := selectOrganizations(cmd.Context())
+
+			if err != nil {
+				return err
+			}
+
+			orgs = selected
+		}
+
+		for _, org := range orgs {
+			addOrganization(cmd.Context(), org)
+		}
+
+		return nil
+	},
+}
+
+// selectOrganizations asks the organizations to add among the ones the token is a member of.
+func selectOrganizations(ctx context.Context) ([]string, error) {
+	ghc, err := clientFor("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.Prefix = "Getting the list of organizations "
+	s.Start()
+	orgs, err := ghc.Organizations(ctx)
+	s.Stop()
+
+	if err != nil {
+		return nil, fmt.Errorf("getting the organizations: %w", err)
+	}
+
+	if len(orgs) == 0 {
+		return nil, errors.New("the token is not a member of any organization, add one by name with `orc org add <organization>`")
+	}
+
+	options := make([]string, len(orgs))
+
+	for i, org := range orgs {
+		options[i] = org
+
+		if conf.Organizations.Exists(org) {
+			options[i] += addedMark
+		}
+	}
+
+	var selected []string
+
+	prompt := &survey.MultiSelect{
+		Message: "Select organizations to add:",
+		Options: options,
+	}
+
+	if err := survey.AskOne(prompt, &selected, survey.WithPageSize(pageSize)); err != nil {
+		return nil, err
+	}
+
+	for i, org := range selected {
+		selected[i] = strings.TrimSuffix(org, addedMark)
+	}
+
+	return selected, nil
+}
+
+// validateOrganization checks the organization or the other source on GitHub and returns it as spelled by GitHub.
+func validateOrganization(ctx context.Context, org string) (string, error) {
+	ghc, err := clientFor(org)
+
+	if err != nil {
+		return "", err
+	}
+
+	source, err := ghc.ResolveSource(ctx, org)
+
+	if errors.Is(err, client.ErrSourceNotFound) {
+		return "", fmt.Errorf("%s does not exist or is not visible to the token", org)
+	}
+
+	return source, err
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/markdown"
+	"github.com/Aykutfgoktas/orc/utils"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// fetchRepositories lists the repositories of the organization or the other source with the spinner.
+func fetchRepositories(ctx context.Context, source string) (*client.RepositoriesResult, error) {
+	ghc, err := clientFor(source)
+
+	if err != nil {
+		return nil, fmt.Errorf("creating the client for %s: %w", source, err)
+	}
+
+	s.Prefix = "Getting the list of repositories from " + source + " "
+	s.Start()
+	repos, err := ghc.Repositories(ctx, source)
+	s.Stop()
+
+	if err != nil {
+		return nil, fmt.Errorf("getting the repositories from %s: %w", source, err)
+	}
+
+	cacheRepositories(source, repos)
+
+	return repos, nil
+}
+
+// selectRepositories asks the repositories to clone among the listed ones.
+func selectRepositories(repos *client.RepositoriesResult) ([]client.Repository, error) {
+	if len(repos.Repositories) == 0 {
+		return nil, fmt.Errorf("there is no repository to select")
+	}
+
+	var names []string
+
+	prompt := &survey.MultiSelect{
+		Message: "Select repositories to clone:",
+		Options: repositoryOptions(repos),
+	}
+
+	if err := survey.AskOne(prompt, &names, survey.WithPageSize(pageSize)); err != nil {
+		return nil, err
+	}
+
+	selected := make([]client.Repository, len(names))
+
+	for i, name := range names {
+		selected[i] = optionRepository(repos, name)
+	}
+
+	return selected, nil
+}
+
+// repositoryOptions returns the picker options, the recently cloned repositories of the listing come first.
+func repositoryOptions(repos *client.RepositoriesResult) []string {
+	names := repos.RepositoryNames()
+	recent := map[int]bool{}
+
+	var options []string
+
+	for _, c := range recentClones() {
+		if len(options) == recentInPicker {
+			break
+		}
+
+		for i, r := range repos.Repositories {
+			if !recent[i] && strings.EqualFold(r.Owner+"/"+r.Name, c.FullName()) {
+				recent[i] = true
+				options = append(options, recentMark+names[i])
+			}
+		}
+	}
+
+	for i, name := range names {
+		if !recent[i] {
+			options = append(options, name)
+		}
+	}
+
+	return options
+}
+
+// optionRepository returns the repository of the picker option.
+func optionRepository(repos *client.RepositoriesResult, option string) client.Repository {
+	return repos.FindRepoByName(strings.TrimPrefix(option, recentMark))
+}
+
+// cloneRepository clones the repository into the current directory with the spinner, the error is left to the caller to report.
+func cloneRepository(ctx context.Context, repo client.Repository) error {
+	s.Prefix = "Cloning the repository " + repo.Name + " "
+	s.Start()
+	err := repo.Clone(ctx)
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("cloning %s: %w", repo.Name, err)
+	}
+
+	fmt.Printf("Repository successfully cloned %s \n", repo.Name)
+	recordClone(repo, repo.Dir())
+
+	return nil
+}
+
+// cloneRepositories clones the repositories one by one and reports the failed ones at the end,
+// it stops when the context is cancelled.
+func cloneRepositories(ctx context.Context, repos []client.Repository) error {
+	failed := 0
+
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := cloneRepository(ctx, repo); err != nil {
+			fmt.Printf("Error while %v \n", err)
+			failed++
+		}
+	}
+
+	if len(repos) > 1 {
+		fmt.Printf("Cloned %d of %d repositories \n", len(repos)-failed, len(repos))
+	} else if len(repos) == 1 && failed == 0 {
+		changeDir(repos[0].Dir())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories could not be cloned", failed, len(repos))
+	}
+
+	return nil
+}
+
+var actionClone = "Clone"
+var actionOpen = "Open in the browser"
+var actionCopy = "Copy the clone URL"
+var actionReadme = "Show the README"
+var actionPulls = "List the open pull requests"
+
+// repositoryAction asks what to do with the repository selected in the picker and runs it.
+func repositoryAction(ctx context.Context, repo client.Repository) error {
+	var action string
+
+	prompt := &survey.Select{
+		Message: "Select the action for " + repo.Owner + "/" + repo.Name + ":",
+		Options: []string{actionClone, actionOpen, actionCopy, actionReadme, actionPulls},
+	}
+
+	if err := survey.AskOne(prompt, &action); err != nil {
+		return err
+	}
+
+	switch action {
+	case actionOpen:
+		if err := utils.OpenBrowser(repo.URL); err != nil {
+			return fmt.Errorf("opening %s: %w", repo.URL, err)
+		}
+	case actionCopy:
+		if err := utils.CopyToClipboard(repo.SSHUrl); err != nil {
+			return fmt.Errorf("copying the clone URL: %w", err)
+		}
+
+		fmt.Printf("Copied %s \n", repo.SSHUrl)
+	case actionReadme:
+		return showReadme(ctx, repo)
+	case actionPulls:
+		return showPullRequests(ctx, repo)
+	default:
+		if err := cloneRepository(ctx, repo); err != nil {
+			return err
+		}
+
+		changeDir(repo.Dir())
+	}
+
+	return nil
+}
+
+func showReadme(ctx context.Context, repo client.Repository) error {
+	ghc, err := clientFor(repo.Owner)
+
+	if err != nil {
+		return err
+	}
+
+	s.Prefix = "Getting the README of " + repo.Name + " "
+	s.Start()
+	readme, err := ghc.Readme(ctx, repo.Owner, repo.Name)
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("getting the README of %s/%s: %w", repo.Owner, repo.Name, err)
+	}
+
+	fmt.Print(renderMarkdown(readme))
+
+	return nil
+}
+
+func showPullRequests(ctx context.Context, repo client.Repository) error {
+	ghc, err := clientFor(repo.Owner)
+
+	if err != nil {
+		return err
+	}
+
+	s.Prefix = "Getting the pull requests of " + repo.Name + " "
+	s.Start()
+	pulls, err := ghc.PullRequests(ctx, repo.Owner, repo.Name)
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("getting the pull requests of %s/%s: %w", repo.Owner, repo.Name, err)
+	}
+
+	if len(pulls) == 0 {
+		fmt.Printf("No open pull requests in %s/%s \n", repo.Owner, repo.Name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "#\tTITLE\tAUTHOR\tUPDATED\tURL")
+
+	for _, pr := range pulls {
+		title := markdown.Sanitize(pr.Title)
+
+		if pr.Draft {
+			title = "[draft] " + title
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", pr.Number, title, pr.Author, ago(pr.UpdatedAt), pr.URL)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/markdown"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var reviewRequested bool
+
+func init() {
+	prCmd.Flags().BoolVar(&reviewRequested, "review", false, "list the pull requests requesting your review, in every organization unless one is given")
+
+	prCmd.ValidArgsFunction = completeOrganizations
+
+	RootCmd.AddCommand(prCmd)
+}
+
+var prCmd = &cobra.Command{
+	Use:   "pr [organization]",
+	Short: "Check out an open pull request of the organization",
+	Long: "List the open pull requests of the organization, the default organization when it is not given, " +
+		"and check out the selected one.\n\n" +
+		"The repository is cloned into the current directory unless it is found in the clone history or the workspace. " +
+		"The pull request, including the ones from forks, is checked out to the pr-<number> branch.",
+	Example: "orc pr my-company\n" +
+		"orc pr --review",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := conf.DefaultOrganization
+
+		if len(args) == 1 {
+			source = args[0]
+		}
+
+		query := "is:pr is:open archived:false"
+
+		if reviewRequested {
+			query += " review-requested:@me"
+		}
+
+		if !reviewRequested || len(args) == 1 {
+			qualifier, err := ownerQualifier(source)
+
+			if err != nil {
+				return err
+			}
+
+			query += " " + qualifier
+		}
+
+		ghc, err := clientFor(source)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = "Searching the pull requests "
+		s.Start()
+		pulls, total, err := ghc.SearchPullRequests(cmd.Context(), query)
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("searching the pull requests: %w", err)
+		}
+
+		if len(pulls) == 0 {
+			fmt.Println("No open pull requests found")
+			return nil
+		}
+
+		if total > len(pulls) {
+			fmt.Printf("Showing %d of %d pull requests \n", len(pulls), total)
+		}
+
+		pr, err := selectPullRequest(pulls)
+
+		if err != nil {
+			return err
+		}
+
+		dir, err := pullRequestClone(cmd.Context(), pr.Repository)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = fmt.Sprintf("Checking out #%d ", pr.Number)
+		s.Start()
+		branch, err := workspace.Open(cmd.Context(), dir).CheckoutPullRequest(cmd.Context(), pr.Number)
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("checking out #%d in %s: %w", pr.Number, dir, err)
+		}
+
+		fmt.Printf("Checked out #%d %s to the branch %s in %s \n", pr.Number, markdown.Sanitize(pr.Title), branch, dir)
+		changeDir(dir)
+
+		return nil
+	},
+}
+
+func selectPullRequest(pulls []client.PullRequest) (client.PullRequest, error) {
+	options := make([]string, len(pulls))
+	byOption := map[string]client.PullRequest{}
+
+	for i, pr := range pulls {
+		options[i] = fmt.Sprintf("%s/%s#%d %s (%s, %s)", pr.Repository.Owner, pr.Repository.Name, pr.Number, markdown.Sanitize(pr.Title), pr.Author, ago(pr.UpdatedAt))
+		byOption[options[i]] = pr
+	}
+
+	var selected string
+
+	prompt := &survey.Select{
+		Message: "Select a pull request to check out:",
+		Options: options,
+	}
+
+	if err := survey.AskOne(prompt, &selected, survey.WithPageSize(pageSize)); err != nil {
+		return client.PullRequest{}, err
+	}
+
+	return byOption[selected], nil
+}
+
+// pullRequestClone returns the directory of the repository, which is cloned into the current directory
+// when it is not cloned yet.
+func pullRequestClone(ctx context.Context, repo client.Repository) (string, error) {
+	name := repo.Owner + "/" + repo.Name
+
+	dir, err := findClone(ctx, name)
+
+	if !errors.Is(err, errNotCloned) {
+		return dir, err
+	}
+
+	ghc, err := clientFor(repo.Owner)
+
+	if err != nil {
+		return "", err
+	}
+
+	// The search results do not carry the repository id recorded in the clone.
+	full, err := ghc.Repository(ctx, repo.Owner, repo.Name)
+
+	if err != nil {
+		return "", fmt.Errorf("getting the repository %s: %w", name, err)
+	}
+
+	if err := cloneRepository(ctx, *full); err != nil {
+		return "", err
+	}
+
+	return full.Dir(), nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/config"
+
+	"github.com/spf13/cobra"
+)
+
+var profileHost string
+var profileApp config.Profile
+
+func init() {
+	profileAddCmd.Flags().StringVar(&profileHost, "host", "", "GitHub host of the profile, github.com when empty")
+	profileAddCmd.Flags().Int64Var(&profileApp.AppID, "app-id", 0, "authenticate as the GitHub App with the given id")
+	profileAddCmd.Flags().Int64Var(&profileApp.InstallationID, "installation-id", 0, "installation id of the GitHub App")
+	profileAddCmd.Flags().StringVar(&profileApp.PrivateKey, "private-key", "", "path of the private key PEM file of the GitHub App")
+
+	profileCmd.AddCommand(profileAddCmd, profileListCmd, profileRemoveCmd, profileBindCmd)
+	RootCmd.AddCommand(profileCmd)
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the GitHub account profiles",
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a profile",
+	Example: "orc profile add work --host github.example.com\n" +
+		"orc profile add ci --app-id 1234 --installation-id 5678 --private-key ~/ci-bot.pem",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := readProfile(cmd.Context())
+
+		if err != nil {
+			return err
+		}
+
+		if err = confService.AddProfile(args[0], p); err != nil {
+			return err
+		}
+
+		fmt.Printf("Profile %s successfully added \n", args[0])
+
+		return nil
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles and the organizations bound to them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(w, "PROFILE\tHOST\tORGANIZATIONS")
+
+		for _, name := range conf.ProfileNames() {
+			p, _ := conf.Profile(name)
+
+			var orgs []string
+
+			for _, org := range conf.Organizations {
+				if conf.ProfileFor(org) == name {
+					orgs = append(orgs, org)
+				}
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%v\n", name, hostName(p.Host), orgs)
+		}
+
+		return w.Flush()
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove the profile, organizations bound to it fall back to the default profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := confService.DeleteProfile(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Profile %s successfully removed \n", args[0])
+
+		return nil
+	},
+}
+
+var profileBindCmd = &cobra.Command{
+	Use:               "bind <organization> <profile>",
+	Short:             "Bind the organization to the profile",
+	Example:           "orc profile bind my-company work",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeBinding,
+	Run: func(cmd *cobra.Command, args []string) {
+		bindOrganization(args[0], args[1])
+	},
+}
+
+// readProfile asks the key of the profile, GitHub App profiles are validated by minting an installation token.
+func readProfile(ctx context.Context) (config.Profile, error) {
+	if !profileApp.IsApp() {
+		return config.Profile{
+			APIKey: readValidKey(ctx, profileHost, ""),
+			Host:   profileHost,
+		}, nil
+	}
+
+	if profileApp.InstallationID == 0 || profileApp.PrivateKey == "" {
+		return config.Profile{}, errors.New("--installation-id and --private-key are required with --app-id")
+	}
+
+	p := profileApp
+	p.Host = profileHost
+
+	if path, err := filepath.Abs(p.PrivateKey); err == nil {
+		p.PrivateKey = path
+	}
+
+	ghc, err := newClient(p)
+
+	if err != nil {
+		return config.Profile{}, err
+	}
+
+	if err = tokenStatus(ctx, ghc, hostName(p.Host), ""); err != nil {
+		return config.Profile{}, err
+	}
+
+	return p, nil
+}
+
+func bindOrganization(org, name string) {
+	if _, err := conf.Profile(name); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := confService.BindOrganization(org, name); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("Organization %s bound to the profile %s \n", org, name)
+	}
+}
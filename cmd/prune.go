@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var pruneList bool
+var atticDir string
+var includeMissing bool
+
+// stateMissing is the state of the repositories the token cannot find, they are deleted
+// or the token lost the access to them, like an expired or wrongly scoped token.
+var stateMissing = "not found or inaccessible"
+
+var pruneKeep = "Keep them"
+var pruneAttic = "Move them to the attic"
+var pruneDelete = "Delete them"
+
+// staleRepo is a clone of an archived or deleted repository, reason tells why it is kept.
+type staleRepo struct {
+	repo   workspace.Repo
+	state  string
+	reason string
+}
+
+func init() {
+	pruneCmd.Flags().BoolVar(&pruneList, "list", false, "only list the clones of the archived and deleted repositories")
+	pruneCmd.Flags().BoolVar(&includeMissing, "include-missing", false, "also prune the clones of the repositories that are not found, which are deleted or not visible to the token")
+	pruneCmd.Flags().StringVar(&atticDir, "attic", "", "directory the clones are moved to, .attic in the workspace when empty")
+	pruneCmd.Flags().StringVarP(&workspacePath, "workspace", "w", "", "directory of the cloned repositories, the configured workspace or the current directory when empty")
+
+	RootCmd.AddCommand(pruneCmd)
+}
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete or move away the clones of the archived and deleted repositories",
+	Long: "Delete or move away the clones of the archived and deleted repositories.\n\n" +
+		"Clones with uncommitted changes, stashes or commits that are not pushed are always kept. " +
+		"The repositories that are not found may only be invisible to the token, their clones are kept unless --include-missing is given.",
+	Example: "orc prune --list\n" +
+		"orc prune --attic ~/attic",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, err := workspaceRepos(cmd.Context())
+
+		if err != nil {
+			return err
+		}
+
+		stale := staleRepos(cmd.Context(), repos)
+
+		if err := cmd.Context().Err(); err != nil {
+			return err
+		}
+
+		if len(stale) == 0 {
+			fmt.Println("No clone of an archived or deleted repository in the workspace")
+			return nil
+		}
+
+		if err := printStale(stale); err != nil {
+			return err
+		}
+
+		var removable []workspace.Repo
+
+		for _, st := range stale {
+			if st.reason == "" {
+				removable = append(removable, st.repo)
+			}
+		}
+
+		if pruneList || len(removable) == 0 {
+			return nil
+		}
+
+		return prune(removable)
+	},
+}
+
+// staleRepos returns the clones of the repositories that are archived or missing on GitHub.
+// The missing ones are looked up one by one, renamed and transferred repositories are not stale.
+func staleRepos(ctx context.Context, repos []workspace.Repo) []staleRepo {
+	byOwner := map[string][]workspace.Repo{}
+	var owners []string
+
+	for _, repo := range repos {
+		if _, ok := byOwner[repo.Owner]; !ok {
+			owners = append(owners, repo.Owner)
+		}
+
+		byOwner[repo.Owner] = append(byOwner[repo.Owner], repo)
+	}
+
+	var stale []staleRepo
+
+	for _, owner := range owners {
+		source := ownerSource(owner)
+
+		// A token that cannot see the organization would report every repository as not found.
+		if err := checkSource(ctx, source); err != nil {
+			fmt.Printf("Skipping the repositories of %s, error: %v \n", owner, err)
+			continue
+		}
+
+		listed, err := fetchRepositories(ctx, source)
+
+		if err != nil {
+			fmt.Printf("Skipping the repositories of %s, error: %v \n", owner, err)
+			continue
+		}
+
+		index := map[string]client.Repository{}
+
+		for _, r := range listed.Repositories {
+			index[strings.ToLower(r.Name)] = r
+		}
+
+		for _, repo := range byOwner[owner] {
+			state, err := repoState(ctx, source, repo, index)
+
+			if err != nil {
+				fmt.Printf("Skipping %s, error: %v \n", repo.FullName(), err)
+				continue
+			}
+
+			if state == "" {
+				continue
+			}
+
+			reason := keepReason(ctx, repo)
+
+			if state == stateMissing && !includeMissing && reason == "" {
+				reason = "not found, use --include-missing to prune"
+			}
+
+			stale = append(stale, staleRepo{repo: repo, state: state, reason: reason})
+		}
+	}
+
+	return stale
+}
+
+// checkSource confirms the token can see the source before its repositories are taken as missing.
+func checkSource(ctx context.Context, source string) error {
+	ghc, err := clientFor(source)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ghc.ResolveSource(ctx, source)
+
+	return err
+}
+
+// repoState returns archived or stateMissing, or an empty string when the repository is active.
+func repoState(ctx context.Context, source string, repo workspace.Repo, index map[string]client.Repository) (string, error) {
+	r, ok := index[strings.ToLower(repo.Name)]
+
+	if !ok {
+		ghc, err := clientFor(source)
+
+		if err != nil {
+			return "", err
+		}
+
+		found, err := ghc.Repository(ctx, repo.Owner, repo.Name)
+
+		if errors.Is(err, client.ErrRepositoryNotFound) {
+			return stateMissing, nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		r = *found
+	}
+
+	if r.Archived {
+		return "archived", nil
+	}
+
+	return "", nil
+}
+
+// keepReason returns why the clone must be kept, or an empty string when it can be removed safely.
+func keepReason(ctx context.Context, repo workspace.Repo) string {
+	st, err := repo.Status(ctx)
+
+	if err != nil {
+		return firstLine(err.Error())
+	}
+
+	unpushed, err := repo.Unpushed(ctx)
+
+	if err != nil {
+		return firstLine(err.Error())
+	}
+
+	var reasons []string
+
+	if st.Dirty > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d changed files", st.Dirty))
+	}
+
+	if st.Stashes > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d stashes", st.Stashes))
+	}
+
+	if unpushed > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d unpushed commits", unpushed))
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+func printStale(stale []staleRepo) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "REPOSITORY\tGITHUB\tLOCAL")
+
+	for _, st := range stale {
+		local := "can be pruned"
+
+		if st.reason != "" {
+			local = "kept: " + st.reason
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", st.repo.FullName(), st.state, local)
+	}
+
+	return w.Flush()
+}
+
+// prune asks what to do with the clones and deletes them or moves them to the attic.
+func prune(repos []workspace.Repo) error {
+	attic, err := atticPath()
+
+	if err != nil {
+		return err
+	}
+
+	var action string
+
+	prompt := &survey.Select{
+		Message: fmt.Sprintf("What to do with the %d clones that can be pruned?", len(repos)),
+		Options: []string{pruneKeep, pruneAttic + " (" + attic + ")", pruneDelete},
+	}
+
+	if err := survey.AskOne(prompt, &action); err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		switch {
+		case action == pruneDelete:
+			err = os.RemoveAll(repo.Path)
+		case strings.HasPrefix(action, pruneAttic):
+			err = moveToAttic(repo, attic)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("pruning %s: %w", repo.FullName(), err)
+		}
+
+		fmt.Printf("Pruned %s \n", repo.FullName())
+	}
+
+	return nil
+}
+
+// atticPath returns the attic directory, a hidden directory of the workspace is not scanned again.
+func atticPath() (string, error) {
+	if atticDir != "" {
+		return atticDir, nil
+	}
+
+	dir, err := workspaceDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, ".attic"), nil
+}
+
+func moveToAttic(repo workspace.Repo, attic string) error {
+	target := filepath.Join(attic, repo.FullName())
+
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("%s already exists", target)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	return os.Rename(repo.Path, target)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/history"
+
+	"github.com/spf13/cobra"
+)
+
+var recentLimit = 20
+
+// recentInPicker is the number of recently cloned repositories at the top of the picker.
+var recentInPicker = 5
+var recentMark = "recent: "
+
+var clones = history.New(cfile.New(cfile.StateFile(history.File)))
+
+// errNotCloned is returned when the repository is found neither in the clone history nor in the workspace.
+var errNotCloned = errors.New("is not cloned")
+
+func init() {
+	recentCmd.Flags().IntVarP(&recentLimit, "limit", "n", recentLimit, "number of clones to show")
+
+	RootCmd.AddCommand(recentCmd, cdCmd)
+}
+
+var recentCmd = &cobra.Command{
+	Use:         "recent",
+	Short:       "List the recently cloned repositories",
+	Annotations: map[string]string{skipSetup: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := clones.List()
+
+		if err != nil {
+			return fmt.Errorf("reading the clone history: %w", err)
+		}
+
+		if len(list) == 0 {
+			fmt.Println("No repository cloned yet")
+			return nil
+		}
+
+		if len(list) > recentLimit {
+			list = list[:recentLimit]
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(w, "REPOSITORY\tPATH\tCLONED")
+
+		for _, c := range list {
+			path := c.Path
+
+			if _, err := os.Stat(path); err != nil {
+				path += " (missing)"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\n", c.FullName(), path, ago(c.ClonedAt))
+		}
+
+		return w.Flush()
+	},
+}
+
+var cdCmd = &cobra.Command{
+	Use:   "cd <repository>",
+	Short: "Print the directory of the cloned repository given as org/repo or repo",
+	Long: "Print the directory of the cloned repository given as org/repo or repo.\n\n" +
+		"The most recent clone is taken from the clone history, the workspace is searched for the others.",
+	Example:           `cd "$(orc cd api)"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeClones,
+	Annotations:       map[string]string{skipSetup: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := findClone(cmd.Context(), args[0])
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(dir)
+		changeDir(dir)
+
+		return nil
+	},
+}
+
+// findClone returns the directory of the repository given as org/repo or repo, the most recent clone
+// is taken from the clone history and the workspace is searched for the others.
+func findClone(ctx context.Context, name string) (string, error) {
+	list, err := clones.List()
+
+	if err != nil {
+		return "", fmt.Errorf("reading the clone history: %w", err)
+	}
+
+	if c, ok := history.Find(list, name); ok {
+		return c.Path, nil
+	}
+
+	repos, err := scanWorkspace(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, repo := range repos {
+		if strings.EqualFold(repo.FullName(), name) || strings.EqualFold(filepath.Base(repo.Path), name) {
+			return repo.Path, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s %w", name, errNotCloned)
+}
+
+// recordClone adds the clone to the history, the clone is not failed when the history cannot be written.
+func recordClone(repo client.Repository, dir string) {
+	if path, err := filepath.Abs(dir); err == nil {
+		dir = path
+	}
+
+	err := clones.Add(history.Clone{
+		Org:      repo.Owner,
+		Repo:     repo.Name,
+		Path:     dir,
+		ClonedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error while recording the clone of %s: %v \n", repo.Name, err)
+	}
+}
+
+// recentClones returns the clone history, the history is optional for the picker.
+func recentClones() []history.Clone {
+	list, _ := clones.List()
+
+	return list
+}
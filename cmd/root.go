@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -23,10 +24,14 @@ var set bool
 var list bool
 var add string
 var remove bool
+var configPath string
+var profile string
+var timeout time.Duration
+var cancelTimeout context.CancelFunc = func() {}
 
 var s *spinner.Spinner
 var conf config.Config
-var ghc client.IGithubClient
+var clients = map[string]client.IGithubClient{}
 var confService config.Service
 
 var spinnerChoice = 9
@@ -34,9 +39,14 @@ var spinnerDuration = 100 * time.Millisecond
 
 var pageSize = 100
 
-var configFile = ".orc.conf.json"
+var keyAttempts = 3
 
 var version = "0.8.0"
+var envProfile = "ORC_PROFILE"
+
+// skipSetup is the command annotation to run the command without asking the initial configuration.
+var skipSetup = "skipSetup"
+
 var use = "orc"
 var description = "List repositories in a GitHub organization and clone the selected repository"
 var example = "orc -l"
@@ -46,46 +56,173 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&list, "list", "l", false, "list organizations")
 	RootCmd.PersistentFlags().BoolVarP(&set, "set", "s", false, "set default organization")
 	RootCmd.PersistentFlags().BoolVarP(&remove, "remove", "r", false, "remove organization")
+	RootCmd.PersistentFlags().StringVar(&configPath, "config", "", "configuration file (json, yaml or toml), overrides "+cfile.EnvConfig)
+	RootCmd.PersistentFlags().StringVarP(&profile, "profile", "p", os.Getenv(envProfile), "profile to use instead of the one bound to the organization")
+	RootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "abort the command after the given duration like 30s or 5m, 0 disables it")
+
+	_ = RootCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
 
 	s = spinner.New(spinner.CharSets[spinnerChoice], spinnerDuration)
-	home, _ := os.UserHomeDir()
 
-	cfile := cfile.New(home + "/" + configFile)
+	client.DefaultRetryPolicy.Wait = countdownWait
+}
 
-	confService = config.New(cfile)
+// initConfig loads the configuration before every command and runs the setup when it does not exist,
+// commands annotated with skipSetup run without the configuration file.
+func initConfig(cmd *cobra.Command, args []string) error {
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		cancelTimeout = cancel
+		cmd.SetContext(ctx)
+	}
+
+	system, user, project := configFiles()
+
+	confService = config.NewLayered(system, user, project)
 
 	isOk := confService.CheckConfigFile()
 
+	if !isOk && skipsSetup(cmd) {
+		// Shared values like the OAuth app client id still come from the system and project files.
+		if c, err := config.NewLayered(system, nil, project).Read(); err == nil {
+			conf = *c
+		}
+
+		return nil
+	}
+
 	if !isOk {
-		conf = readInput()
+		conf = readInput(cmd.Context())
 
-		path, err := confService.Create(conf.APIKey, conf.DefaultOrganization)
+		_, err := confService.Create(conf.APIKey, conf.DefaultOrganization)
 
 		if err != nil {
 			fmt.Printf("Error on creating the config: %v \n", err)
 		} else {
-			fmt.Printf("Config file created to here: %s \n", path)
+			fmt.Printf("Config file created to here: %s \n", confService.ConfigFile())
 		}
 	} else {
-		c, _ := confService.Read()
+		c, err := confService.Read()
+
+		if err != nil {
+			log.Fatal("Error reading the config: ", err)
+		}
+
 		conf = *c
 	}
 
-	ghc = client.NewGithubClient(conf.APIKey)
+	return nil
+}
+
+// configFiles returns the system, user and project configuration files, the optional ones are nil when missing.
+func configFiles() (system, user, project cfile.IConfigFile) {
+	user = cfile.New(cfile.Locate(configPath))
+
+	if file := cfile.SystemFile(); file != "" {
+		system = cfile.New(file)
+	}
+
+	if dir, err := os.Getwd(); err == nil {
+		if file := cfile.ProjectFile(dir); file != "" {
+			project = cfile.New(file)
+		}
+	}
+
+	return system, user, project
+}
+
+// skipsSetup reports whether the command runs without the configuration file, the shell completions
+// run in the background and must not prompt.
+func skipsSetup(cmd *cobra.Command) bool {
+	if cmd.Annotations[skipSetup] == "true" {
+		return true
+	}
+
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientFor returns the client of the selected profile, or the profile bound to the organization.
+// Clients are created on demand and reused for the same profile.
+func clientFor(org string) (client.IGithubClient, error) {
+	return profileClient(profileName(org))
+}
+
+// profileName returns the selected profile, or the profile bound to the organization.
+func profileName(org string) string {
+	if profile != "" {
+		return profile
+	}
+
+	name := conf.ProfileFor(org)
+
+	// Teams and users without a binding of their own use the profile of their owner.
+	if name == config.DefaultProfile {
+		name = conf.ProfileFor(client.ParseSource(org).Owner)
+	}
+
+	return name
+}
+
+// profileClient returns the client of the profile, clients are created on demand and reused.
+func profileClient(name string) (client.IGithubClient, error) {
+	if ghc, ok := clients[name]; ok {
+		return ghc, nil
+	}
+
+	p, err := conf.Profile(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ghc, err := newClient(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	clients[name] = ghc
+
+	return ghc, nil
+}
+
+func newClient(p config.Profile) (client.IGithubClient, error) {
+	if !p.IsApp() {
+		return client.NewGithubClientForHost(p.APIKey, p.Host)
+	}
+
+	key, err := os.ReadFile(p.PrivateKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("reading the private key of the GitHub App: %w", err)
+	}
+
+	return client.NewGithubAppClient(p.AppID, p.InstallationID, key, p.Host)
 }
 
 var RootCmd = &cobra.Command{
-	Use:   use,
-	Short: description,
+	Use:               use,
+	Short:             description,
+	PersistentPreRunE: initConfig,
+	// The errors are printed once by main, most of them are not usage errors.
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		if list {
-			listOrganization()
+			listOrganization(cmd.Context())
 			return nil
 		}
 
 		if add != "" {
-			addOrganization(add)
+			addOrganization(cmd.Context(), add)
 			return nil
 		}
 
@@ -99,14 +236,36 @@ var RootCmd = &cobra.Command{
 			return nil
 		}
 
-		listRepo(conf.DefaultOrganization)
+		listRepo(cmd.Context(), conf.DefaultOrganization)
 		return nil
 	},
 	Example: example,
 	Version: version,
 }
 
-func readInput() config.Config {
+// Execute runs the command with the context, the timeout context is released also when the command fails,
+// cobra skips the post run hooks after an error.
+func Execute(ctx context.Context) error {
+	defer func() { cancelTimeout() }()
+
+	return RootCmd.ExecuteContext(ctx)
+}
+
+func readInput(ctx context.Context) config.Config {
+	fmt.Println("Run `orc auth login` to authenticate in the browser instead of entering an API key.")
+
+	org := readOrganization()
+
+	key := readValidKey(ctx, "", org)
+
+	return config.Config{
+		APIKey:              key,
+		DefaultOrganization: org,
+		Organizations:       []string{org},
+	}
+}
+
+func readOrganization() string {
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Print("Enter the organization name: ")
 
@@ -118,21 +277,55 @@ func readInput() config.Config {
 		log.Fatal("Error reading input:", err)
 	}
 
+	return org
+}
+
+func readKey() string {
 	fmt.Print("Enter the Github API Key: ")
 
 	key, err := term.ReadPassword(int(os.Stdin.Fd()))
 
+	fmt.Println()
+
 	if err != nil {
 		log.Fatal("Error reading input:", err)
 	}
 
-	return config.Config{
-		APIKey:              string(key),
-		DefaultOrganization: org,
-		Organizations:       []string{org},
+	return string(key)
+}
+
+// readValidKey asks the key until it is accepted by the GitHub API and reports its scopes.
+func readValidKey(ctx context.Context, host, org string) string {
+	for attempt := 1; ; attempt++ {
+		key := readKey()
+
+		ghc, err := client.NewGithubClientForHost(key, host)
+
+		if err == nil {
+			err = tokenStatus(ctx, ghc, hostName(host), org)
+		}
+
+		if err == nil {
+			return key
+		}
+
+		fmt.Println(err)
+
+		if attempt == keyAttempts {
+			log.Fatal("Could not validate the Github API Key")
+		}
 	}
 }
 
+// hostName returns the host for display, github.com when it is empty.
+func hostName(host string) string {
+	if host == "" {
+		return "github.com"
+	}
+
+	return host
+}
+
 func setDefaultOrganization() {
 	fmt.Printf("Current default organization is: %s \n", conf.DefaultOrganization)
 
@@ -149,21 +342,51 @@ func setDefaultOrganization() {
 
 	if err := confService.UpdateDefaultOrganization(selectedOrg); err != nil {
 		fmt.Println(err)
-	} else {
-		fmt.Printf("Organization has been selected as default: %s \n", selectedOrg)
+		return
+	}
+
+	fmt.Printf("Organization has been selected as default: %s \n", selectedOrg)
+
+	warnShadowed("org", selectedOrg)
+}
+
+// warnShadowed warns when the value just written to the user file is overridden by a
+// higher layer, the project file or the environment, and so has no effect here.
+func warnShadowed(key, written string) {
+	values, err := confService.Show()
+
+	if err != nil {
+		return
+	}
+
+	for _, v := range values {
+		if v.Key == key && v.Value != written {
+			fmt.Printf("Warning: %s is overridden by %s with %s \n", key, v.Origin, v.Value)
+		}
 	}
 }
 
-func addOrganization(org string) {
+func addOrganization(ctx context.Context, org string) {
+	org, err := validateOrganization(ctx, org)
+
+	if err != nil {
+		fmt.Printf("Error while adding the organization: %v \n", err)
+		return
+	}
+
 	isOk, _ := confService.AddOrganization(org)
 	if isOk {
-		fmt.Printf("Oranization %s is already in the list, operation will be ignored", org)
+		fmt.Printf("Oranization %s is already in the list, operation will be ignored \n", org)
 	} else {
-		fmt.Printf("Oranization %s successfully added", org)
+		fmt.Printf("Oranization %s successfully added \n", org)
+	}
+
+	if profile != "" {
+		bindOrganization(org, profile)
 	}
 }
 
-func listOrganization() {
+func listOrganization(ctx context.Context) {
 	var org string
 
 	prompt := &survey.Select{
@@ -175,20 +398,16 @@ func listOrganization() {
 		log.Fatal("Error selecting organization:", "error", err)
 	}
 
-	listRepo(org)
+	listRepo(ctx, org)
 }
 
-func listRepo(org string) {
+func listRepo(ctx context.Context, org string) {
 	utils.ClearTerminal()
 
-	s.Prefix = "Getting the list of repositories from " + org + " "
-
-	s.Start()
-	repos, err := ghc.Repositories(org)
-	s.Stop()
+	repos, err := fetchRepositories(ctx, org)
 
 	if err != nil {
-		fmt.Printf("Error while getting the repositories from %s: %s \n", org, err.Error())
+		fmt.Printf("Error while %v \n", err)
 		return
 	}
 
@@ -196,23 +415,15 @@ func listRepo(org string) {
 
 	prompt := &survey.Select{
 		Message: "Select a repository to clone:",
-		Options: repos.RepositoryNames(),
+		Options: repositoryOptions(repos),
 	}
 
 	if err := survey.AskOne(prompt, &selectedRepo, survey.WithPageSize(pageSize)); err != nil {
 		log.Fatal("Error selecting repository:", "error", err)
 	}
 
-	repo := repos.FindRepoByName(selectedRepo)
-	s.Prefix = "Cloning the repository " + repo.Name + " "
-	s.Start()
-	err = repo.Clone()
-	s.Stop()
-
-	if err != nil {
-		fmt.Printf("Error while cloning the repo %s, error: %v \n", repo.Name, err)
-	} else {
-		fmt.Printf("Repository successfully cloned %s \n", repo.Name)
+	if err := repositoryAction(ctx, optionRepository(repos, selectedRepo)); err != nil {
+		fmt.Printf("Error while %v \n", err)
 	}
 }
 
@@ -228,11 +439,42 @@ func deleteOrganization() {
 		log.Fatal("Error selecting organization:", "error", err)
 	}
 
+	origin := organizationOrigin(org)
+
+	// Only the user file is written, the organizations of the project file and the environment stay.
+	if strings.HasPrefix(origin, config.OriginProject) || strings.HasPrefix(origin, config.OriginEnv) {
+		fmt.Printf("Error while deleting the organization %s, it is set by %s \n", org, origin)
+		return
+	}
+
 	err := confService.DeleteOrganization(org)
 
 	if err != nil {
 		fmt.Printf("Error while deleting the organization %s, error: %v \n", org, err)
-	} else {
-		fmt.Printf("Organization successfully deleted %s \n", org)
+		return
 	}
+
+	if origin := organizationOrigin(org); origin != "" {
+		fmt.Printf("Warning: %s is deleted from the user file but still set by %s \n", org, origin)
+		return
+	}
+
+	fmt.Printf("Organization successfully deleted %s \n", org)
+}
+
+// organizationOrigin returns the origin of the organization in the merged configuration, empty when it is not configured.
+func organizationOrigin(org string) string {
+	values, err := confService.Show()
+
+	if err != nil {
+		return ""
+	}
+
+	for _, v := range values {
+		if v.Key == "orgs" && v.Value == org {
+			return v.Origin
+		}
+	}
+
+	return ""
 }
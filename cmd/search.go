@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/client"
+
+	"github.com/spf13/cobra"
+)
+
+var orgQualifier = "org:"
+
+// searchLimit is the number of repositories searched for, the results past the search cap of 1000
+// take a call of the rate limited search API for every split of the query.
+var searchLimit = 1000
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", searchLimit, "maximum number of repositories to fetch")
+
+	RootCmd.AddCommand(searchCmd)
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search repositories with the GitHub search syntax and clone the selected ones",
+	Long: "Search repositories with the GitHub search syntax and clone the selected ones.\n\n" +
+		"The query accepts the qualifiers of the repository search like org:, topic:, language: and pushed:,\n" +
+		"see https://docs.github.com/search-github/searching-on-github/searching-for-repositories",
+	Example: "orc search 'org:my-company topic:kafka language:go pushed:>2023-05-01'\n" +
+		"orc search 'language:go stars:>100' --limit 3000",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if searchLimit < 1 {
+			return fmt.Errorf("the limit must be at least 1, got %d", searchLimit)
+		}
+
+		repos, err := searchRepositories(cmd.Context(), args[0])
+
+		if err != nil {
+			return err
+		}
+
+		selected, err := selectRepositories(repos)
+
+		if err != nil {
+			return err
+		}
+
+		return cloneRepositories(cmd.Context(), selected)
+	},
+}
+
+// searchRepositories runs the search with the profile of the organization in the query.
+func searchRepositories(ctx context.Context, query string) (*client.RepositoriesResult, error) {
+	ghc, err := clientFor(queryOrganization(query))
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.Prefix = "Searching the repositories "
+	s.Start()
+	repos, total, err := ghc.SearchRepositories(ctx, query, searchLimit)
+	s.Stop()
+
+	if err != nil {
+		return nil, fmt.Errorf("searching the repositories: %w", err)
+	}
+
+	if total > len(repos.Repositories) {
+		fmt.Printf("Showing %d of %d repositories, narrow the query or raise --limit to see the rest \n", len(repos.Repositories), total)
+	}
+
+	return repos, nil
+}
+
+// queryOrganization returns the organization of the org: qualifier, or an empty string.
+func queryOrganization(query string) string {
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, orgQualifier) {
+			return strings.TrimPrefix(field, orgQualifier)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// envCdFile is set by the shell wrapper to the file orc writes the directory to change into.
+var envCdFile = "ORC_CD_FILE"
+
+var posixWrapper = `orc() {
+  local orc_cd_file orc_status
+  orc_cd_file="$(mktemp)" || return
+  ORC_CD_FILE="$orc_cd_file" command orc "$@"
+  orc_status=$?
+  if [ -s "$orc_cd_file" ]; then
+    cd "$(cat "$orc_cd_file")" || orc_status=$?
+  fi
+  rm -f "$orc_cd_file"
+  return $orc_status
+}
+`
+
+var fishWrapper = `function orc
+    set -l orc_cd_file (mktemp); or return
+    env ORC_CD_FILE=$orc_cd_file orc $argv
+    set -l orc_status $status
+    if test -s $orc_cd_file
+        cd (cat $orc_cd_file)
+    end
+    rm -f $orc_cd_file
+    return $orc_status
+end
+`
+
+func init() {
+	RootCmd.AddCommand(shellInitCmd)
+}
+
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init bash|zsh|fish",
+	Short: "Print the shell function changing into the cloned repository and the shell completions",
+	Long: "Print the shell function changing into the cloned repository and the shell completions.\n\n" +
+		"Add the output to the shell startup file, after a single repository is cloned or with orc cd\n" +
+		"the shell changes into its directory.",
+	Example: `eval "$(orc shell-init bash)"   # ~/.bashrc` + "\n" +
+		`eval "$(orc shell-init zsh)"    # ~/.zshrc` + "\n" +
+		`orc shell-init fish | source    # ~/.config/fish/config.fish`,
+	Args:        cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:   []string{"bash", "zsh", "fish"},
+	Annotations: map[string]string{skipSetup: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		switch args[0] {
+		case "bash":
+			fmt.Fprint(out, posixWrapper)
+			return RootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			fmt.Fprint(out, posixWrapper)
+
+			if err := RootCmd.GenZshCompletion(out); err != nil {
+				return err
+			}
+
+			// The generated script only registers itself when it is loaded from fpath.
+			_, err := fmt.Fprintln(out, "compdef _orc orc")
+
+			return err
+		default:
+			// fish resolves "command orc" inside the function, env runs the binary instead.
+			fmt.Fprint(out, fishWrapper)
+			return RootCmd.GenFishCompletion(out, true)
+		}
+	},
+}
+
+// changeDir asks the shell wrapper to change into the directory, it does nothing without the wrapper.
+func changeDir(dir string) {
+	file := os.Getenv(envCdFile)
+
+	if file == "" {
+		return
+	}
+
+	if path, err := filepath.Abs(dir); err == nil {
+		dir = path
+	}
+
+	if err := os.WriteFile(file, []byte(dir), 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error while changing into %s: %v \n", dir, err)
+	}
+}
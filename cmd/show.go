@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/markdown"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var noReadme bool
+
+func init() {
+	showCmd.Flags().BoolVar(&noReadme, "no-readme", false, "show only the details without the README")
+
+	RootCmd.AddCommand(showCmd)
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <repository>",
+	Short: "Show the details and the README of the repository",
+	Long: "Show the details and the README of the repository without cloning it.\n\n" +
+		"The repository is given as org/repo, or as repo in the default organization. " +
+		"The README is colored when the output is a terminal and NO_COLOR is not set.",
+	Example: "orc show my-company/api\n" +
+		"orc show api | less -R",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, name, err := parseRepository(args[0])
+
+		if err != nil {
+			return err
+		}
+
+		ghc, err := clientFor(owner)
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = "Getting the repository " + owner + "/" + name + " "
+		s.Start()
+		details, err := ghc.RepositoryDetails(cmd.Context(), owner, name)
+
+		var readme string
+
+		if err == nil && !noReadme {
+			readme, err = ghc.Readme(cmd.Context(), owner, name)
+
+			if errors.Is(err, client.ErrReadmeNotFound) {
+				readme, err = "", nil
+			}
+		}
+		s.Stop()
+
+		if err != nil {
+			return fmt.Errorf("getting the repository %s/%s: %w", owner, name, err)
+		}
+
+		if err := printDetails(details); err != nil {
+			return err
+		}
+
+		if noReadme {
+			return nil
+		}
+
+		fmt.Println()
+
+		if readme == "" {
+			fmt.Println("The repository has no README")
+			return nil
+		}
+
+		fmt.Print(renderMarkdown(readme))
+
+		return nil
+	},
+}
+
+func printDetails(d *client.RepositoryDetails) error {
+	fmt.Println(d.Owner + "/" + d.Name)
+
+	if d.Description != "" {
+		fmt.Println(markdown.Sanitize(d.Description))
+	}
+
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "URL\t%s\n", d.URL)
+	fmt.Fprintf(w, "Default branch\t%s\n", d.DefaultBranch)
+	fmt.Fprintf(w, "Language\t%s\n", dash(d.Language))
+	fmt.Fprintf(w, "Topics\t%s\n", dash(strings.Join(d.Topics, ", ")))
+	fmt.Fprintf(w, "License\t%s\n", dash(d.License))
+	fmt.Fprintf(w, "Stars\t%d\n", d.Stars)
+	fmt.Fprintf(w, "Forks\t%d\n", d.Forks)
+	fmt.Fprintf(w, "Open issues\t%s\n", count(d.OpenIssues))
+	fmt.Fprintf(w, "Open pull requests\t%s\n", count(d.OpenPulls))
+
+	release := "-"
+
+	if d.LatestRelease != "" {
+		release = d.LatestRelease + " (" + ago(d.ReleasedAt) + ")"
+	}
+
+	fmt.Fprintf(w, "Latest release\t%s\n", release)
+	fmt.Fprintf(w, "Last push\t%s\n", ago(d.PushedAt))
+	fmt.Fprintf(w, "Top contributors\t%s\n", dash(strings.Join(d.Contributors, ", ")))
+
+	if d.Archived {
+		fmt.Fprintln(w, "Archived\tyes")
+	}
+
+	return w.Flush()
+}
+
+// count returns the count for display, - when it is unknown.
+func count(n *int) string {
+	if n == nil {
+		return "-"
+	}
+
+	return strconv.Itoa(*n)
+}
+
+// renderMarkdown renders the markdown for the output, colored only on a terminal without NO_COLOR.
+func renderMarkdown(src string) string {
+	_, noColor := os.LookupEnv("NO_COLOR")
+
+	return markdown.Render(src, !noColor && term.IsTerminal(int(os.Stdout.Fd())))
+}
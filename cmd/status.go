@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/spf13/cobra"
+)
+
+var offline bool
+
+// repoStatus is the local status and the state on GitHub of a repository in the workspace.
+type repoStatus struct {
+	repo   workspace.Repo
+	status workspace.Status
+	err    error
+	github string
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&workspacePath, "workspace", "w", "", "directory of the cloned repositories, the configured workspace or the current directory when empty")
+	statusCmd.Flags().BoolVar(&allRepos, "all", false, "show the repositories of every owner, not only the configured organizations")
+	statusCmd.Flags().BoolVar(&offline, "offline", false, "do not check the repositories on GitHub")
+	statusCmd.Flags().IntVarP(&jobs, "jobs", "j", jobs, "number of repositories checked at the same time")
+
+	RootCmd.AddCommand(statusCmd)
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of the cloned repositories in the workspace",
+	Example: "orc status\n" +
+		"orc status --workspace ~/src --all",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, err := workspaceRepos(cmd.Context())
+
+		if err != nil {
+			return err
+		}
+
+		if len(repos) == 0 {
+			fmt.Println("No repository of the configured organizations in the workspace")
+			return nil
+		}
+
+		s.Prefix = fmt.Sprintf("Checking %d repositories ", len(repos))
+		s.Start()
+		statuses := workspaceStatus(cmd.Context(), repos)
+		s.Stop()
+
+		if err := cmd.Context().Err(); err != nil {
+			return err
+		}
+
+		return printStatus(statuses)
+	},
+}
+
+// workspaceStatus checks the repositories in parallel.
+func workspaceStatus(ctx context.Context, repos []workspace.Repo) []repoStatus {
+	var ghcs map[string]client.IGithubClient
+	var errs map[string]error
+
+	if !offline {
+		ghcs, errs = ownerClients(repoOwners(repos))
+	}
+
+	statuses := make([]repoStatus, len(repos))
+
+	workspace.ForEach(repos, jobs, func(i int, repo workspace.Repo) {
+		st := repoStatus{repo: repo}
+		st.status, st.err = repo.Status(ctx)
+
+		if ghc := ghcs[repo.Owner]; ghc != nil {
+			st.github = githubState(ctx, ghc, repo)
+		} else if err := errs[repo.Owner]; err != nil {
+			st.github = "error: " + firstLine(err.Error())
+		}
+
+		statuses[i] = st
+	})
+
+	return statuses
+}
+
+// ownerSource returns the configured source of the owner, the owner as an organization when it is not configured.
+func ownerSource(owner string) string {
+	if source := sourceOf(owner); source != "" {
+		return source
+	}
+
+	return owner
+}
+
+// githubState returns moved, archived, deleted or ok for the repository on GitHub.
+func githubState(ctx context.Context, ghc client.IGithubClient, repo workspace.Repo) string {
+	r, err := currentRepository(ctx, ghc, repo)
+
+	switch {
+	case errors.Is(err, client.ErrRepositoryNotFound):
+		return "deleted"
+	case err != nil:
+		return "error: " + firstLine(err.Error())
+	case moved(repo, r):
+		return "moved to " + r.Owner + "/" + r.Name
+	case r.Archived:
+		return "archived"
+	default:
+		return "ok"
+	}
+}
+
+func printStatus(statuses []repoStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "REPOSITORY\tBRANCH\tUPSTREAM\tDIRTY\tSTASH\tFETCHED\tGITHUB")
+
+	for _, st := range statuses {
+		if st.err != nil {
+			fmt.Fprintf(w, "%s\terror: %s\t\t\t\t\t%s\n", st.repo.FullName(), firstLine(st.err.Error()), dash(st.github))
+			continue
+		}
+
+		branch := st.status.Branch
+
+		if branch == "" {
+			branch = "(detached)"
+		}
+
+		upstream := "-"
+
+		if st.status.Upstream != "" {
+			upstream = fmt.Sprintf("+%d -%d", st.status.Ahead, st.status.Behind)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\n", st.repo.FullName(), branch, upstream,
+			st.status.Dirty, st.status.Stashes, ago(st.status.LastFetch), dash(st.github))
+	}
+
+	return w.Flush()
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
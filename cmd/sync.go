@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/workspace"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var assumeYes bool
+
+// movedRepo is a clone of a repository renamed or transferred to current.
+type movedRepo struct {
+	repo    workspace.Repo
+	current *client.Repository
+}
+
+func init() {
+	syncCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "fix the remotes and move the directories without asking")
+	syncCmd.Flags().StringVarP(&workspacePath, "workspace", "w", "", "directory of the cloned repositories, the configured workspace or the current directory when empty")
+	syncCmd.Flags().IntVarP(&jobs, "jobs", "j", jobs, "number of repositories checked at the same time")
+
+	RootCmd.AddCommand(syncCmd)
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fix the remotes and the directories of the clones of renamed and transferred repositories",
+	Long: "Fix the remotes and the directories of the clones of renamed and transferred repositories.\n\n" +
+		"The repositories cloned by orc are found by their id, the others by the redirect of their old name.\n" +
+		"The id of the clones without one is recorded on the way.",
+	Example: "orc sync\n" +
+		"orc sync --workspace ~/src --yes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, err := workspaceRepos(cmd.Context())
+
+		if err != nil {
+			return err
+		}
+
+		s.Prefix = fmt.Sprintf("Checking %d repositories ", len(repos))
+		s.Start()
+		moves, failures := movedRepos(cmd.Context(), repos)
+		s.Stop()
+
+		if err := cmd.Context().Err(); err != nil {
+			return err
+		}
+
+		for _, err := range failures {
+			fmt.Printf("Skipping %v \n", err)
+		}
+
+		if len(moves) == 0 {
+			if len(failures) == 0 {
+				fmt.Println("No clone of a renamed or transferred repository in the workspace")
+			}
+
+			return nil
+		}
+
+		for _, m := range moves {
+			if err := fixMoved(cmd.Context(), m); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+// currentRepository returns the repository of the clone by its id, or by its name following the redirects.
+func currentRepository(ctx context.Context, ghc client.IGithubClient, repo workspace.Repo) (*client.Repository, error) {
+	if repo.ID != 0 {
+		return ghc.RepositoryByID(ctx, repo.ID)
+	}
+
+	return ghc.Repository(ctx, repo.Owner, repo.Name)
+}
+
+// moved reports whether the repository is renamed or transferred since it was cloned.
+func moved(repo workspace.Repo, current *client.Repository) bool {
+	return !strings.EqualFold(repo.FullName(), current.Owner+"/"+current.Name)
+}
+
+// movedRepos checks the repositories in parallel and records the id of the clones without one,
+// it returns the moved repositories and the errors of the repositories that could not be checked.
+func movedRepos(ctx context.Context, repos []workspace.Repo) ([]movedRepo, []error) {
+	ghcs, clientErrs := ownerClients(repoOwners(repos))
+
+	found := make([]*client.Repository, len(repos))
+	errs := make([]error, len(repos))
+
+	workspace.ForEach(repos, jobs, func(i int, repo workspace.Repo) {
+		ghc := ghcs[repo.Owner]
+
+		if ghc == nil {
+			if err := clientErrs[repo.Owner]; err != nil {
+				errs[i] = fmt.Errorf("%s, error: %s", repo.FullName(), firstLine(err.Error()))
+			}
+
+			return
+		}
+
+		current, err := currentRepository(ctx, ghc, repo)
+
+		if errors.Is(err, client.ErrRepositoryNotFound) {
+			return
+		}
+
+		if err != nil {
+			errs[i] = fmt.Errorf("%s, error: %s", repo.FullName(), firstLine(err.Error()))
+			return
+		}
+
+		if repo.ID == 0 {
+			_ = repo.SetID(ctx, current.ID)
+		}
+
+		found[i] = current
+	})
+
+	var moves []movedRepo
+	var failures []error
+
+	for i, repo := range repos {
+		if found[i] != nil && moved(repo, found[i]) {
+			moves = append(moves, movedRepo{repo: repo, current: found[i]})
+		}
+
+		if errs[i] != nil {
+			failures = append(failures, errs[i])
+		}
+	}
+
+	return moves, failures
+}
+
+// fixMoved points the remote to the new name and moves the directory to match the layout.
+func fixMoved(ctx context.Context, m movedRepo) error {
+	newName := m.current.Owner + "/" + m.current.Name
+	target := movedDir(m.repo, m.current)
+
+	fmt.Printf("%s was renamed or transferred to %s \n", m.repo.FullName(), newName)
+
+	if confirm(fmt.Sprintf("Point the origin remote of %s to %s?", m.repo.Path, newName)) {
+		if err := m.repo.SetRemote(ctx, m.current.Owner, m.current.Name); err != nil {
+			return fmt.Errorf("fixing the remote of %s: %w", m.repo.Path, err)
+		}
+
+		fmt.Printf("Remote of %s points to %s \n", m.repo.Path, m.repo.Remote)
+	}
+
+	if target == m.repo.Path {
+		return nil
+	}
+
+	if _, err := os.Stat(target); err == nil {
+		fmt.Printf("Not moving %s, %s already exists \n", m.repo.Path, target)
+		return nil
+	}
+
+	if !confirm(fmt.Sprintf("Move %s to %s?", m.repo.Path, target)) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	if err := os.Rename(m.repo.Path, target); err != nil {
+		return fmt.Errorf("moving %s: %w", m.repo.Path, err)
+	}
+
+	fmt.Printf("Moved %s to %s \n", m.repo.Path, target)
+
+	return nil
+}
+
+// movedDir returns the directory of the clone after the move. Clones in a directory of their owner
+// move to the directory of the new owner, clones in a directory not named after the repository stay.
+func movedDir(repo workspace.Repo, current *client.Repository) string {
+	parent := filepath.Dir(repo.Path)
+
+	if !strings.EqualFold(filepath.Base(repo.Path), repo.Name) {
+		return repo.Path
+	}
+
+	if strings.EqualFold(filepath.Base(parent), repo.Owner) {
+		return filepath.Join(filepath.Dir(parent), current.Owner, current.Name)
+	}
+
+	return filepath.Join(parent, current.Name)
+}
+
+// confirm asks the question, every question is accepted with --yes.
+func confirm(question string) bool {
+	if assumeYes {
+		return true
+	}
+
+	ok := false
+
+	if err := survey.AskOne(&survey.Confirm{Message: question, Default: true}, &ok); err != nil {
+		return false
+	}
+
+	return ok
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Aykutfgoktas/orc/client"
+)
+
+// countdownWait shows the remaining time while the client waits for a retry or a rate limit reset,
+// the countdown is shown next to the spinner when it is running.
+func countdownWait(ctx context.Context, d time.Duration, reason string) error {
+	if d < time.Second {
+		return client.Sleep(ctx, d, reason)
+	}
+
+	deadline := time.Now().Add(d)
+	ticker := time.NewTicker(time.Second)
+
+	defer ticker.Stop()
+	defer showCountdown("")
+
+	for {
+		remaining := time.Until(deadline).Round(time.Second)
+
+		if remaining <= 0 {
+			return nil
+		}
+
+		showCountdown(fmt.Sprintf("%s, retrying in %s", reason, remaining))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func showCountdown(msg string) {
+	if s.Active() {
+		s.Lock()
+		s.Suffix = " " + msg
+		s.Unlock()
+
+		return
+	}
+
+	// \033[K clears the rest of the previous countdown line.
+	if msg == "" {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	} else {
+		fmt.Fprintf(os.Stderr, "\r\033[K%s", msg)
+	}
+}
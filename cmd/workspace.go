@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Aykutfgoktas/orc/client"
+	"github.com/Aykutfgoktas/orc/workspace"
+)
+
+var workspacePath string
+var allRepos bool
+var jobs = 8
+
+// workspaceDir returns the workspace from the flag or the configuration, the current directory when both are empty.
+func workspaceDir() (string, error) {
+	dir := workspacePath
+
+	if dir == "" {
+		dir = conf.Workspace
+	}
+
+	if dir == "" {
+		return os.Getwd()
+	}
+
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return "", err
+		}
+
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+
+	return dir, nil
+}
+
+// scanWorkspace returns every repository in the workspace.
+func scanWorkspace(ctx context.Context) ([]workspace.Repo, error) {
+	dir, err := workspaceDir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := workspace.Scan(ctx, dir)
+
+	if err != nil {
+		return nil, fmt.Errorf("scanning the workspace %s: %w", dir, err)
+	}
+
+	return repos, nil
+}
+
+// workspaceRepos returns the repositories in the workspace owned by the configured organizations and users,
+// or all of them with --all.
+func workspaceRepos(ctx context.Context) ([]workspace.Repo, error) {
+	repos, err := scanWorkspace(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if allRepos {
+		return repos, nil
+	}
+
+	var configured []workspace.Repo
+
+	for _, repo := range repos {
+		if sourceOf(repo.Owner) != "" {
+			configured = append(configured, repo)
+		}
+	}
+
+	return configured, nil
+}
+
+// sourceOf returns the configured organization or user owning the repositories of the owner, or an empty string.
+func sourceOf(owner string) string {
+	if owner == "" {
+		return ""
+	}
+
+	for _, source := range conf.Organizations {
+		if strings.EqualFold(client.ParseSource(source).Owner, owner) {
+			return source
+		}
+	}
+
+	return ""
+}
+
+// ownerClients creates the client of every owner up front, clientFor is not safe for concurrent use.
+// The owners whose client could not be created have the error instead.
+func ownerClients(owners []string) (map[string]client.IGithubClient, map[string]error) {
+	ghcs := map[string]client.IGithubClient{}
+	errs := map[string]error{}
+
+	for _, owner := range owners {
+		_, done := ghcs[owner]
+
+		if done || errs[owner] != nil || owner == "" {
+			continue
+		}
+
+		ghc, err := clientFor(ownerSource(owner))
+
+		if err != nil {
+			errs[owner] = err
+			continue
+		}
+
+		ghcs[owner] = ghc
+	}
+
+	return ghcs, errs
+}
+
+// repoOwners returns the owners of the repositories.
+func repoOwners(repos []workspace.Repo) []string {
+	owners := make([]string, len(repos))
+
+	for i, repo := range repos {
+		owners[i] = repo.Owner
+	}
+
+	return owners
+}
+
+// ago returns the time passed since t for display.
+func ago(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	d := time.Since(t)
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// firstLine returns the first line of the message for the tables, git errors span several lines.
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+
+	return line
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+)
+
+// Origins of the configuration values, from the lowest to the highest precedence.
+const (
+	OriginSystem  = "system"
+	OriginUser    = "user"
+	OriginProject = "project"
+	OriginEnv     = "env"
+)
+
+// Environment variables overriding the configuration values.
+const (
+	EnvKey  = "ORC_KEY"
+	EnvOrg  = "ORC_ORG"
+	EnvOrgs = "ORC_ORGS"
+
+	EnvClientID  = "ORC_CLIENT_ID"
+	EnvWorkspace = "ORC_WORKSPACE"
+)
+
+// Value is a single configuration value and the place it comes from.
+type Value struct {
+	Key    string
+	Value  string
+	Origin string
+}
+
+type layer struct {
+	origin string
+	file   cfile.IConfigFile
+}
+
+// source is the layer a value comes from, layer is nil for the environment variables.
+type source struct {
+	layer *layer
+	env   string
+}
+
+// merged holds the merged configuration and the source of every value keyed by the value name.
+type merged struct {
+	conf    Config
+	sources map[string]source
+}
+
+func (s source) String() string {
+	if s.layer == nil {
+		if s.env == "" {
+			return ""
+		}
+
+		return fmt.Sprintf("%s (%s)", OriginEnv, s.env)
+	}
+
+	return fmt.Sprintf("%s (%s)", s.layer.origin, s.layer.file.ConfigFile())
+}
+
+// merge reads the layers on top of each other and applies the environment variables last.
+// Only the user file is required, missing system and project files are skipped, and the
+// project file only contributes the organization settings.
+func (c *config) merge() (*merged, error) {
+	m := &merged{sources: map[string]source{}}
+
+	for i := range c.layers {
+		l := &c.layers[i]
+
+		if l.file != c.cfile && !l.file.CheckConfigFile() {
+			continue
+		}
+
+		result, err := l.file.Reader()
+
+		if err != nil {
+			return nil, err
+		}
+
+		conf := Config{}
+
+		if err = result.Decode(&conf); err != nil {
+			return nil, decodeError(err)
+		}
+
+		if l.origin == OriginProject {
+			conf = conf.shared()
+		}
+
+		m.apply(conf, l)
+	}
+
+	m.apply(envConfig(), nil)
+
+	return m, nil
+}
+
+// shared keeps only the organization settings of the configuration. The project file comes with
+// any checkout, also of third-party repositories, so it must not set the keys, the profiles or the
+// client id that decide where the tokens are sent.
+func (c Config) shared() Config {
+	return Config{
+		DefaultOrganization: c.DefaultOrganization,
+		Organizations:       c.Organizations,
+		Bindings:            c.Bindings,
+		Workspace:           c.Workspace,
+	}
+}
+
+func (m *merged) apply(conf Config, l *layer) {
+	if conf.APIKey != "" {
+		m.conf.APIKey = conf.APIKey
+		m.sources["key"] = source{l, EnvKey}
+	}
+
+	if conf.DefaultOrganization != "" {
+		m.conf.DefaultOrganization = conf.DefaultOrganization
+		m.sources["org"] = source{l, EnvOrg}
+	}
+
+	if conf.ClientID != "" {
+		m.conf.ClientID = conf.ClientID
+		m.sources["client_id"] = source{l, EnvClientID}
+	}
+
+	if conf.Workspace != "" {
+		m.conf.Workspace = conf.Workspace
+		m.sources["workspace"] = source{l, EnvWorkspace}
+	}
+
+	for _, org := range conf.Organizations {
+		if !m.conf.Organizations.Exists(org) {
+			m.conf.Organizations.Add(org)
+			m.sources["orgs."+org] = source{l, EnvOrgs}
+		}
+	}
+
+	m.applyProfiles(conf, l)
+}
+
+func (m *merged) applyProfiles(conf Config, l *layer) {
+	for name, p := range conf.Profiles {
+		if m.conf.Profiles == nil {
+			m.conf.Profiles = Profiles{}
+		}
+
+		current := m.conf.Profiles[name]
+
+		if p.APIKey != "" {
+			current.APIKey = p.APIKey
+			m.sources["profiles."+name+".key"] = source{l, ""}
+		}
+
+		if p.Host != "" {
+			current.Host = p.Host
+			m.sources["profiles."+name+".host"] = source{l, ""}
+		}
+
+		if p.IsApp() {
+			current.AppID = p.AppID
+			current.InstallationID = p.InstallationID
+			current.PrivateKey = p.PrivateKey
+			m.sources["profiles."+name+".app"] = source{l, ""}
+		}
+
+		m.conf.Profiles[name] = current
+	}
+
+	for org, name := range conf.Bindings {
+		if m.conf.Bindings == nil {
+			m.conf.Bindings = Bindings{}
+		}
+
+		m.conf.Bindings[org] = name
+		m.sources["bindings."+org] = source{l, ""}
+	}
+}
+
+func (m *merged) values() []Value {
+	values := []Value{
+		{Key: "key", Value: m.conf.APIKey, Origin: m.sources["key"].String()},
+		{Key: "org", Value: m.conf.DefaultOrganization, Origin: m.sources["org"].String()},
+		{Key: "client_id", Value: m.conf.ClientID, Origin: m.sources["client_id"].String()},
+		{Key: "workspace", Value: m.conf.Workspace, Origin: m.sources["workspace"].String()},
+	}
+
+	for _, org := range m.conf.Organizations {
+		values = append(values, Value{Key: "orgs", Value: org, Origin: m.sources["orgs."+org].String()})
+	}
+
+	for _, name := range sortedKeys(m.conf.Profiles) {
+		p := m.conf.Profiles[name]
+		key := "profiles." + name
+
+		values = append(values,
+			Value{Key: key + ".key", Value: p.APIKey, Origin: m.sources[key+".key"].String()},
+			Value{Key: key + ".host", Value: p.Host, Origin: m.sources[key+".host"].String()},
+		)
+
+		if p.IsApp() {
+			app := fmt.Sprintf("app %d, installation %d, private key %s", p.AppID, p.InstallationID, p.PrivateKey)
+
+			values = append(values, Value{Key: key + ".app", Value: app, Origin: m.sources[key+".app"].String()})
+		}
+	}
+
+	for _, org := range sortedKeys(m.conf.Bindings) {
+		key := "bindings." + org
+
+		values = append(values, Value{Key: key, Value: m.conf.Bindings[org], Origin: m.sources[key].String()})
+	}
+
+	return values
+}
+
+func envConfig() Config {
+	conf := Config{
+		APIKey:              os.Getenv(EnvKey),
+		DefaultOrganization: os.Getenv(EnvOrg),
+		ClientID:            os.Getenv(EnvClientID),
+		Workspace:           os.Getenv(EnvWorkspace),
+	}
+
+	for _, org := range strings.Split(os.Getenv(EnvOrgs), ",") {
+		if org = strings.TrimSpace(org); org != "" {
+			conf.Organizations.Add(org)
+		}
+	}
+
+	return conf
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Layered config", func() {
+	var (
+		dir     string
+		system  string
+		user    string
+		project string
+		service Service
+	)
+
+	write := func(file, content string) {
+		Expect(os.WriteFile(file, []byte(content), 0600)).To(BeNil())
+	}
+
+	BeforeEach(func() {
+		dir, _ = os.MkdirTemp("", "orc")
+		system = filepath.Join(dir, "system.toml")
+		user = filepath.Join(dir, "user.json")
+		project = filepath.Join(dir, ".orc.yaml")
+
+		write(user, `{"key":"user-key","org":"user-org","orgs":["user-org"]}`)
+
+		service = NewLayered(cfile.New(system), cfile.New(user), cfile.New(project))
+
+		for _, env := range []string{EnvKey, EnvOrg, EnvOrgs, EnvClientID, EnvWorkspace} {
+			os.Unsetenv(env)
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+
+		for _, env := range []string{EnvKey, EnvOrg, EnvOrgs, EnvClientID, EnvWorkspace} {
+			os.Unsetenv(env)
+		}
+	})
+
+	It("should read the user file when the other layers do not exist", func() {
+		result, err := service.Read()
+
+		Expect(err).To(BeNil())
+		Expect(result.APIKey).To(Equal("user-key"))
+		Expect(result.DefaultOrganization).To(Equal("user-org"))
+		Expect(result.Organizations).To(Equal(Organizations{"user-org"}))
+	})
+
+	It("should return the error when the user file does not exist", func() {
+		os.Remove(user)
+
+		_, err := service.Read()
+
+		Expect(err).To(Not(BeNil()))
+	})
+
+	It("should merge the layers by precedence", func() {
+		write(system, "key = \"system-key\"\norg = \"system-org\"\norgs = [\"shared\"]\n")
+		write(project, "org: project-org\norgs:\n  - project-org\n  - user-org\n")
+
+		result, err := service.Read()
+
+		Expect(err).To(BeNil())
+		Expect(result.APIKey).To(Equal("user-key"))
+		Expect(result.DefaultOrganization).To(Equal("project-org"))
+		Expect(result.Organizations).To(Equal(Organizations{"shared", "user-org", "project-org"}))
+	})
+
+	It("should not take the credentials from the project file", func() {
+		write(user, `{"key":"user-key","org":"user-org","orgs":["user-org"],"profiles":{"work":{"key":"work-key","host":"github.example.com"}}}`)
+		write(project, "key: project-key\nclient_id: project-client\nprofiles:\n  work:\n    host: evil.example\n  other:\n    key: other-key\nbindings:\n  project-org: work\n")
+
+		result, err := service.Read()
+
+		Expect(err).To(BeNil())
+		Expect(result.APIKey).To(Equal("user-key"))
+		Expect(result.ClientID).To(BeEmpty())
+		Expect(result.Profiles).To(Equal(Profiles{"work": {APIKey: "work-key", Host: "github.example.com"}}))
+		Expect(result.Bindings).To(Equal(Bindings{"project-org": "work"}))
+	})
+
+	It("should apply the environment variables last", func() {
+		write(project, "org: project-org\n")
+
+		os.Setenv(EnvKey, "env-key")
+		os.Setenv(EnvOrg, "env-org")
+		os.Setenv(EnvOrgs, "a, b,,user-org")
+
+		result, err := service.Read()
+
+		Expect(err).To(BeNil())
+		Expect(result.APIKey).To(Equal("env-key"))
+		Expect(result.DefaultOrganization).To(Equal("env-org"))
+		Expect(result.Organizations).To(Equal(Organizations{"user-org", "a", "b"}))
+	})
+
+	It("should show the origin of each value", func() {
+		write(system, "orgs = [\"shared\"]\n")
+		write(project, "org: project-org\n")
+
+		os.Setenv(EnvOrgs, "env-org")
+		os.Setenv(EnvWorkspace, "/src")
+
+		values, err := service.Show()
+
+		Expect(err).To(BeNil())
+		Expect(values).To(Equal([]Value{
+			{Key: "key", Value: "user-key", Origin: "user (" + user + ")"},
+			{Key: "org", Value: "project-org", Origin: "project (" + project + ")"},
+			{Key: "client_id", Value: "", Origin: ""},
+			{Key: "workspace", Value: "/src", Origin: "env (" + EnvWorkspace + ")"},
+			{Key: "orgs", Value: "shared", Origin: "system (" + system + ")"},
+			{Key: "orgs", Value: "user-org", Origin: "user (" + user + ")"},
+			{Key: "orgs", Value: "env-org", Origin: "env (" + EnvOrgs + ")"},
+		}))
+	})
+
+	It("should show the project value shadowing the updated default organization", func() {
+		write(project, "org: project-org\n")
+
+		Expect(service.UpdateDefaultOrganization("new-org")).To(BeNil())
+
+		values, err := service.Show()
+
+		Expect(err).To(BeNil())
+		Expect(values[1]).To(Equal(Value{Key: "org", Value: "project-org", Origin: "project (" + project + ")"}))
+
+		b, _ := os.ReadFile(user)
+
+		Expect(string(b)).To(ContainSubstring("new-org"))
+	})
+
+	It("should write the updates only to the user file", func() {
+		write(project, "orgs:\n  - project-org\n")
+
+		_, err := service.AddOrganization("new-org")
+
+		Expect(err).To(BeNil())
+
+		b, _ := os.ReadFile(user)
+
+		Expect(string(b)).To(Not(ContainSubstring("project-org")))
+		Expect(string(b)).To(ContainSubstring("new-org"))
+	})
+})
@@ -17,9 +17,12 @@ type Service interface {
 	// Create creates the configuration file.
 	Create(apikey, org string) (string, error)
 
-	// Read reads the configration file content.
+	// Read reads the configration merged from the system, user and project files and the environment.
 	Read() (*Config, error)
 
+	// Show returns the merged configuration values with the origin of each value.
+	Show() ([]Value, error)
+
 	// UpdateDefaultOrganization updates default organization on the configuration.
 	UpdateDefaultOrganization(org string) error
 
@@ -28,6 +31,18 @@ type Service interface {
 
 	// DeleteOrganization deletes the selected organization.
 	DeleteOrganization(org string) error
+
+	// AddProfile adds or replaces the profile with the given name.
+	AddProfile(name string, p Profile) error
+
+	// DeleteProfile deletes the profile and the organization bindings to it.
+	DeleteProfile(name string) error
+
+	// BindOrganization binds the organization to the profile, the default profile removes the binding.
+	BindOrganization(org, profile string) error
+
+	// UpdateKey replaces the key of the profile, the default profile without an explicit entry updates the top level key.
+	UpdateKey(profile, key string) error
 }
 
 type Organizations []string
@@ -55,19 +70,39 @@ func (a *Organizations) Exists(val string) bool {
 }
 
 type Config struct {
-	APIKey              string        `json:"key"`
-	DefaultOrganization string        `json:"org"`
-	Organizations       Organizations `json:"orgs"`
+	APIKey              string        `json:"key" yaml:"key" toml:"key"`
+	DefaultOrganization string        `json:"org" yaml:"org" toml:"org"`
+	Organizations       Organizations `json:"orgs" yaml:"orgs" toml:"orgs"`
+	Profiles            Profiles      `json:"profiles,omitempty" yaml:"profiles,omitempty" toml:"profiles,omitempty"`
+	Bindings            Bindings      `json:"bindings,omitempty" yaml:"bindings,omitempty" toml:"bindings,omitempty"`
+	ClientID            string        `json:"client_id,omitempty" yaml:"client_id,omitempty" toml:"client_id,omitempty"`
+	Workspace           string        `json:"workspace,omitempty" yaml:"workspace,omitempty" toml:"workspace,omitempty"`
 }
 
 type config struct {
-	cfile cfile.IConfigFile
+	cfile  cfile.IConfigFile
+	layers []layer
 }
 
 func New(cfile cfile.IConfigFile) Service {
-	return &config{
-		cfile: cfile,
+	return NewLayered(nil, cfile, nil)
+}
+
+// NewLayered returns the service merging the system, user and project configuration files.
+// System and project files are optional and can be nil, updates are always written to the user file.
+// Without the user file the service only reads the other layers.
+func NewLayered(system, user, project cfile.IConfigFile) Service {
+	c := &config{
+		cfile: user,
 	}
+
+	for _, l := range []layer{{OriginSystem, system}, {OriginUser, user}, {OriginProject, project}} {
+		if l.file != nil {
+			c.layers = append(c.layers, l)
+		}
+	}
+
+	return c
 }
 
 func (c *config) ConfigFile() string {
@@ -85,6 +120,14 @@ func (c *config) Create(apikey, org string) (string, error) {
 		Organizations:       []string{org},
 	}
 
+	unlock, err := c.cfile.Lock()
+
+	if err != nil {
+		return "", lockError(err)
+	}
+
+	defer unlock() //nolint:errcheck
+
 	path, err := c.cfile.Writer(conf)
 
 	if err != nil {
@@ -95,74 +138,73 @@ func (c *config) Create(apikey, org string) (string, error) {
 }
 
 func (c *config) Read() (*Config, error) {
-	result, err := c.cfile.Reader()
+	m, err := c.merge()
 
 	if err != nil {
 		return nil, err
 	}
 
-	conf := Config{}
-
-	if err = result.Decode(&conf); err != nil {
-		return nil, decodeError(err)
-	}
-
-	return &conf, nil
+	return &m.conf, nil
 }
 
-func (c *config) UpdateDefaultOrganization(org string) error {
-	result, err := c.cfile.Reader()
-
-	if err != nil {
-		return readerError(err)
-	}
-
-	conf := Config{}
-
-	err = result.Decode(&conf)
+func (c *config) Show() ([]Value, error) {
+	m, err := c.merge()
 
 	if err != nil {
-		return decodeError(err)
+		return nil, err
 	}
 
-	conf.DefaultOrganization = org
+	return m.values(), nil
+}
 
-	if _, err = c.cfile.Writer(conf); err != nil {
-		return writerError(err)
-	}
+func (c *config) UpdateDefaultOrganization(org string) error {
+	return c.update(func(conf *Config) bool {
+		conf.DefaultOrganization = org
 
-	return nil
+		return true
+	})
 }
 
 func (c *config) AddOrganization(org string) (bool, error) {
 	flag := false
 
-	result, err := c.cfile.Reader()
+	err := c.update(func(conf *Config) bool {
+		flag = conf.Organizations.Exists(org)
+
+		if !flag {
+			conf.Organizations.Add(org)
+		}
+
+		return !flag
+	})
 
 	if err != nil {
-		return flag, readerError(err)
+		return false, err
 	}
 
-	conf := Config{}
+	return flag, nil
+}
 
-	if err = result.Decode(&conf); err != nil {
-		return flag, decodeError(err)
-	}
+func (c *config) DeleteOrganization(org string) error {
+	return c.update(func(conf *Config) bool {
+		conf.Organizations.Remove(org)
+		delete(conf.Bindings, org)
 
-	flag = conf.Organizations.Exists(org)
+		return true
+	})
+}
 
-	if !flag {
-		conf.Organizations.Add(org)
+// update runs read-modify-write on the configuration file while holding the file lock,
+// the configuration is written only when fn reports a change.
+func (c *config) update(fn func(conf *Config) bool) error {
+	unlock, err := c.cfile.Lock()
 
-		if _, err := c.cfile.Writer(conf); err != nil {
-			return false, writerError(err)
-		}
+	if err != nil {
+		return lockError(err)
 	}
 
-	return flag, nil
-}
+	defer unlock() //nolint:errcheck
 
-func (c *config) DeleteOrganization(org string) error {
 	result, err := c.cfile.Reader()
 
 	if err != nil {
@@ -175,9 +217,11 @@ func (c *config) DeleteOrganization(org string) error {
 		return decodeError(err)
 	}
 
-	conf.Organizations.Remove(org)
+	if !fn(&conf) {
+		return nil
+	}
 
-	if _, err := c.cfile.Writer(conf); err != nil {
+	if _, err = c.cfile.Writer(conf); err != nil {
 		return writerError(err)
 	}
 
@@ -198,3 +242,8 @@ func decodeError(e error) error {
 	m := fmt.Sprintf("Error while decoding the config file error: %s", e.Error())
 	return errors.New(m)
 }
+
+func lockError(e error) error {
+	m := fmt.Sprintf("Error while locking the config file error: %s", e.Error())
+	return errors.New(m)
+}
@@ -3,7 +3,12 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 
+	"github.com/Aykutfgoktas/orc/cfile"
 	"github.com/Aykutfgoktas/orc/cfile/mocks"
 
 	"testing"
@@ -53,6 +58,10 @@ var _ = Describe("Config service", func() {
 		ctrl.Finish()
 	})
 
+	expectLock := func() {
+		configFileService.EXPECT().Lock().Times(1).Return(func() error { return nil }, nil)
+	}
+
 	Describe("NewConfig", func() {
 		It("should get the config service", func() {
 
@@ -86,6 +95,8 @@ var _ = Describe("Config service", func() {
 
 		It("should return the error", func() {
 
+			expectLock()
+
 			configFileService.EXPECT().Writer(conf).Times(1).Return("", errors.New("a"))
 
 			result, err := configService.Create(conf.APIKey, conf.DefaultOrganization)
@@ -96,6 +107,8 @@ var _ = Describe("Config service", func() {
 
 		It("should return the path", func() {
 
+			expectLock()
+
 			configFileService.EXPECT().Writer(conf).Times(1).Return("path", nil)
 
 			result, err := configService.Create(conf.APIKey, conf.DefaultOrganization)
@@ -162,6 +175,8 @@ var _ = Describe("Config service", func() {
 		It("should return the reader error", func() {
 			readerError := readerError(errMsg)
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, errMsg)
 
 			err := configService.UpdateDefaultOrganization(org)
@@ -176,6 +191,8 @@ var _ = Describe("Config service", func() {
 
 			readerMock.EXPECT().Decode(&conff).Times(1).Return(errMsg)
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			err := configService.UpdateDefaultOrganization(org)
@@ -200,6 +217,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			conf.DefaultOrganization = org
@@ -226,6 +245,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			conf.DefaultOrganization = org
@@ -240,9 +261,36 @@ var _ = Describe("Config service", func() {
 	})
 	Describe("AddOrganization", func() {
 
+		It("should return the lock error", func() {
+			lockError := lockError(errMsg)
+
+			configFileService.EXPECT().Lock().Times(1).Return(nil, errMsg)
+
+			_, err := configService.AddOrganization(org)
+
+			Expect(err).To(Equal(lockError))
+		})
+
+		It("should release the lock", func() {
+			released := false
+
+			configFileService.EXPECT().Lock().Times(1).Return(func() error {
+				released = true
+				return nil
+			}, nil)
+
+			configFileService.EXPECT().Reader().Times(1).Return(readerMock, errMsg)
+
+			_, _ = configService.AddOrganization(org)
+
+			Expect(released).To(Equal(true))
+		})
+
 		It("should return the reader error", func() {
 			readerError := readerError(errMsg)
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, errMsg)
 
 			_, err := configService.AddOrganization(org)
@@ -257,6 +305,8 @@ var _ = Describe("Config service", func() {
 
 			readerMock.EXPECT().Decode(&conff).Times(1).Return(errMsg)
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			_, err := configService.AddOrganization(org)
@@ -281,6 +331,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			conf.Organizations.Add(org)
@@ -307,6 +359,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			conf.Organizations.Add(org)
@@ -334,6 +388,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			result, err := configService.AddOrganization(conf.DefaultOrganization)
@@ -349,6 +405,8 @@ var _ = Describe("Config service", func() {
 		It("should return the reader error", func() {
 			readerError := readerError(errMsg)
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, errMsg)
 
 			err := configService.DeleteOrganization(org)
@@ -363,6 +421,8 @@ var _ = Describe("Config service", func() {
 
 			readerMock.EXPECT().Decode(&conff).Times(1).Return(errMsg)
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			err := configService.DeleteOrganization(org)
@@ -387,6 +447,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			removeOrg := conf.Organizations[0]
@@ -415,6 +477,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			configFileService.EXPECT().Writer(conf).Times(1).Return("", nil)
@@ -440,6 +504,8 @@ var _ = Describe("Config service", func() {
 				return nil
 			})
 
+			expectLock()
+
 			configFileService.EXPECT().Reader().Times(1).Return(readerMock, nil)
 
 			removeOrg := conf.Organizations[0]
@@ -499,4 +565,110 @@ var _ = Describe("Config service", func() {
 			Expect(organizations.Exists(organizationDeleted)).To(Equal(false))
 		})
 	})
+
+	Describe("ConcurrentWrites", func() {
+		var (
+			dir     string
+			service Service
+		)
+
+		BeforeEach(func() {
+			dir, _ = os.MkdirTemp("", "orc")
+			service = New(cfile.New(filepath.Join(dir, "config.json")))
+
+			_, err := service.Create(conf.APIKey, conf.DefaultOrganization)
+
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should keep every organization added concurrently", func() {
+			count := 100
+			start := make(chan struct{})
+
+			var wg sync.WaitGroup
+
+			for i := 0; i < count; i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					// Every goroutine gets its own service like separate orc invocations.
+					service := New(cfile.New(filepath.Join(dir, "config.json")))
+
+					<-start
+
+					_, err := service.AddOrganization(fmt.Sprintf("org-%d", i))
+
+					Expect(err).To(BeNil())
+				}(i)
+			}
+
+			close(start)
+			wg.Wait()
+
+			result, err := service.Read()
+
+			Expect(err).To(BeNil())
+			Expect(len(result.Organizations)).To(Equal(count + 1))
+
+			for i := 0; i < count; i++ {
+				Expect(result.Organizations.Exists(fmt.Sprintf("org-%d", i))).To(Equal(true))
+			}
+		})
+
+		It("should keep the configuration consistent with mixed concurrent updates", func() {
+			count := 20
+
+			var wg sync.WaitGroup
+
+			for i := 0; i < count; i++ {
+				wg.Add(3)
+
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					_, err := service.AddOrganization(fmt.Sprintf("org-%d", i))
+
+					Expect(err).To(BeNil())
+				}(i)
+
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					Expect(service.UpdateDefaultOrganization(fmt.Sprintf("org-%d", i))).To(BeNil())
+				}(i)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					_, err := service.Read()
+
+					Expect(err).To(BeNil())
+				}()
+			}
+
+			wg.Wait()
+
+			result, err := service.Read()
+
+			Expect(err).To(BeNil())
+			Expect(len(result.Organizations)).To(Equal(count + 1))
+			Expect(result.APIKey).To(Equal(conf.APIKey))
+
+			entries, _ := os.ReadDir(dir)
+
+			for _, e := range entries {
+				Expect(e.Name()).To(Or(Equal("config.json"), Equal("config.json.lock")))
+			}
+		})
+	})
 })
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultProfile is the name of the profile built from the top level key of the configuration.
+const DefaultProfile = "default"
+
+// Profile holds the credentials of a GitHub account and the host it belongs to.
+// Profiles with an app id authenticate as the GitHub App installation instead of the key.
+type Profile struct {
+	APIKey         string `json:"key" yaml:"key" toml:"key"`
+	Host           string `json:"host,omitempty" yaml:"host,omitempty" toml:"host,omitempty"`
+	AppID          int64  `json:"app_id,omitempty" yaml:"app_id,omitempty" toml:"app_id,omitempty"`
+	InstallationID int64  `json:"installation_id,omitempty" yaml:"installation_id,omitempty" toml:"installation_id,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty" yaml:"private_key,omitempty" toml:"private_key,omitempty"`
+}
+
+// IsApp reports whether the profile authenticates as a GitHub App installation.
+func (p Profile) IsApp() bool {
+	return p.AppID != 0
+}
+
+// Profiles maps the profile names to the profiles.
+type Profiles map[string]Profile
+
+// Bindings maps the organizations to the profile names.
+type Bindings map[string]string
+
+// Profile returns the profile with the given name, an empty name returns the default profile.
+// The default profile falls back to the top level key when it is not defined explicitly.
+func (c *Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	if p, ok := c.Profiles[name]; ok {
+		return p, nil
+	}
+
+	if name == DefaultProfile {
+		return Profile{APIKey: c.APIKey}, nil
+	}
+
+	return Profile{}, fmt.Errorf("profile %s does not exist", name)
+}
+
+// ProfileFor returns the profile name bound to the organization, or the default profile.
+func (c *Config) ProfileFor(org string) string {
+	if name, ok := c.Bindings[org]; ok && name != "" {
+		return name
+	}
+
+	return DefaultProfile
+}
+
+// ProfileNames returns the default profile and the configured profile names.
+func (c *Config) ProfileNames() []string {
+	names := []string{}
+
+	for name := range c.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...)
+}
+
+func (c *config) AddProfile(name string, p Profile) error {
+	if name == "" {
+		return errors.New("profile name can not be empty")
+	}
+
+	return c.update(func(conf *Config) bool {
+		if conf.Profiles == nil {
+			conf.Profiles = Profiles{}
+		}
+
+		conf.Profiles[name] = p
+
+		return true
+	})
+}
+
+func (c *config) DeleteProfile(name string) error {
+	return c.update(func(conf *Config) bool {
+		delete(conf.Profiles, name)
+
+		for org, profile := range conf.Bindings {
+			if profile == name {
+				delete(conf.Bindings, org)
+			}
+		}
+
+		return true
+	})
+}
+
+func (c *config) BindOrganization(org, profile string) error {
+	return c.update(func(conf *Config) bool {
+		if profile == "" || profile == DefaultProfile {
+			delete(conf.Bindings, org)
+
+			return true
+		}
+
+		if conf.Bindings == nil {
+			conf.Bindings = Bindings{}
+		}
+
+		conf.Bindings[org] = profile
+
+		return true
+	})
+}
+
+func (c *config) UpdateKey(name, key string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	var missing bool
+
+	err := c.update(func(conf *Config) bool {
+		p, ok := conf.Profiles[name]
+
+		switch {
+		case ok:
+			p.APIKey = key
+			conf.Profiles[name] = p
+		case name == DefaultProfile:
+			conf.APIKey = key
+		default:
+			missing = true
+			return false
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if missing {
+		return fmt.Errorf("profile %s does not exist", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiles", func() {
+	var (
+		dir     string
+		service Service
+	)
+
+	BeforeEach(func() {
+		dir, _ = os.MkdirTemp("", "orc")
+		service = New(cfile.New(filepath.Join(dir, "config.yaml")))
+
+		_, err := service.Create("legacy-key", "personal")
+
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should fall back to the top level key for the default profile", func() {
+		conf, _ := service.Read()
+
+		p, err := conf.Profile("")
+
+		Expect(err).To(BeNil())
+		Expect(p).To(Equal(Profile{APIKey: "legacy-key"}))
+		Expect(conf.ProfileFor("personal")).To(Equal(DefaultProfile))
+	})
+
+	It("should return the error for an unknown profile", func() {
+		conf, _ := service.Read()
+
+		_, err := conf.Profile("work")
+
+		Expect(err).To(Not(BeNil()))
+	})
+
+	It("should add the profile and bind the organization", func() {
+		Expect(service.AddProfile("work", Profile{APIKey: "work-key", Host: "github.example.com"})).To(BeNil())
+		Expect(service.BindOrganization("company", "work")).To(BeNil())
+
+		conf, _ := service.Read()
+
+		Expect(conf.ProfileNames()).To(Equal([]string{DefaultProfile, "work"}))
+		Expect(conf.ProfileFor("company")).To(Equal("work"))
+
+		p, err := conf.Profile("work")
+
+		Expect(err).To(BeNil())
+		Expect(p.Host).To(Equal("github.example.com"))
+	})
+
+	It("should remove the binding with the default profile", func() {
+		Expect(service.AddProfile("work", Profile{APIKey: "work-key"})).To(BeNil())
+		Expect(service.BindOrganization("company", "work")).To(BeNil())
+		Expect(service.BindOrganization("company", DefaultProfile)).To(BeNil())
+
+		conf, _ := service.Read()
+
+		Expect(conf.ProfileFor("company")).To(Equal(DefaultProfile))
+	})
+
+	It("should remove the bindings of the deleted profile", func() {
+		Expect(service.AddProfile("work", Profile{APIKey: "work-key"})).To(BeNil())
+		Expect(service.BindOrganization("company", "work")).To(BeNil())
+		Expect(service.DeleteProfile("work")).To(BeNil())
+
+		conf, _ := service.Read()
+
+		Expect(conf.Profiles).To(BeEmpty())
+		Expect(conf.Bindings).To(BeEmpty())
+	})
+
+	It("should remove the binding of the deleted organization", func() {
+		_, _ = service.AddOrganization("company")
+
+		Expect(service.AddProfile("work", Profile{APIKey: "work-key"})).To(BeNil())
+		Expect(service.BindOrganization("company", "work")).To(BeNil())
+		Expect(service.DeleteOrganization("company")).To(BeNil())
+
+		conf, _ := service.Read()
+
+		Expect(conf.Bindings).To(BeEmpty())
+	})
+
+	It("should not accept an empty profile name", func() {
+		Expect(service.AddProfile("", Profile{})).To(Not(BeNil()))
+	})
+
+	It("should update the top level key of the default profile", func() {
+		Expect(service.UpdateKey("", "new-key")).To(BeNil())
+
+		conf, _ := service.Read()
+
+		Expect(conf.APIKey).To(Equal("new-key"))
+	})
+
+	It("should update the key of the profile", func() {
+		Expect(service.AddProfile("work", Profile{APIKey: "work-key", Host: "github.example.com"})).To(BeNil())
+		Expect(service.UpdateKey("work", "new-key")).To(BeNil())
+
+		conf, _ := service.Read()
+
+		Expect(conf.Profiles["work"]).To(Equal(Profile{APIKey: "new-key", Host: "github.example.com"}))
+		Expect(conf.APIKey).To(Equal("legacy-key"))
+	})
+
+	It("should return the error when the profile does not exist", func() {
+		Expect(service.UpdateKey("work", "new-key")).To(Not(BeNil()))
+	})
+})
@@ -0,0 +1,111 @@
+package history
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+)
+
+// File is the name of the history file in the state directory.
+var File = "history.json"
+
+// maxEntries is the number of clones the history keeps.
+var maxEntries = 200
+
+// Clone is a repository cloned by orc.
+type Clone struct {
+	Org      string    `json:"org"`
+	Repo     string    `json:"repo"`
+	Path     string    `json:"path"`
+	ClonedAt time.Time `json:"cloned_at"`
+}
+
+// FullName returns org/repo.
+func (e Clone) FullName() string {
+	return e.Org + "/" + e.Repo
+}
+
+// Matches reports whether the entry is the repository given as org/repo or repo, ignoring the case.
+func (e Clone) Matches(name string) bool {
+	return strings.EqualFold(e.FullName(), name) || strings.EqualFold(e.Repo, name)
+}
+
+type Service interface {
+	// Add records the clone, an earlier clone into the same path is replaced.
+	Add(e Clone) error
+
+	// List returns the clones, the most recent first.
+	List() ([]Clone, error)
+}
+
+type history struct {
+	cfile cfile.IConfigFile
+}
+
+func New(cfile cfile.IConfigFile) Service {
+	return &history{
+		cfile: cfile,
+	}
+}
+
+func (h *history) Add(e Clone) error {
+	unlock, err := h.cfile.Lock()
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock() //nolint:errcheck
+
+	entries, err := h.List()
+
+	if err != nil {
+		return err
+	}
+
+	updated := []Clone{e}
+
+	for _, old := range entries {
+		if old.Path != e.Path && len(updated) < maxEntries {
+			updated = append(updated, old)
+		}
+	}
+
+	_, err = h.cfile.Writer(updated)
+
+	return err
+}
+
+func (h *history) List() ([]Clone, error) {
+	if !h.cfile.CheckConfigFile() {
+		return nil, nil
+	}
+
+	result, err := h.cfile.Reader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Clone
+
+	if err = result.Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Find returns the most recent clone of the repository given as org/repo or repo,
+// the clones removed from the disk since are skipped.
+func Find(entries []Clone, name string) (Clone, bool) {
+	for _, e := range entries {
+		if _, err := os.Stat(e.Path); err == nil && e.Matches(name) {
+			return e, true
+		}
+	}
+
+	return Clone{}, false
+}
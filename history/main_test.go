@@ -0,0 +1,101 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Aykutfgoktas/orc/cfile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHistory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "History Suite")
+}
+
+var _ = Describe("History", func() {
+	var service Service
+
+	BeforeEach(func() {
+		service = New(cfile.New(filepath.Join(GinkgoT().TempDir(), "state", File)))
+	})
+
+	It("should return no entry before the first clone", func() {
+		entries, err := service.List()
+
+		Expect(err).To(BeNil())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("should list the most recent clone first and replace the clone into the same path", func() {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		Expect(service.Add(Clone{Org: "acme", Repo: "api", Path: "/src/api", ClonedAt: now.Add(-time.Hour)})).To(Succeed())
+		Expect(service.Add(Clone{Org: "acme", Repo: "web", Path: "/src/web", ClonedAt: now.Add(-time.Minute)})).To(Succeed())
+		Expect(service.Add(Clone{Org: "acme", Repo: "api", Path: "/src/api", ClonedAt: now})).To(Succeed())
+
+		entries, err := service.List()
+
+		Expect(err).To(BeNil())
+		Expect(entries).To(Equal([]Clone{
+			{Org: "acme", Repo: "api", Path: "/src/api", ClonedAt: now},
+			{Org: "acme", Repo: "web", Path: "/src/web", ClonedAt: now.Add(-time.Minute)},
+		}))
+	})
+
+	It("should keep the latest entries", func() {
+		defer func(n int) { maxEntries = n }(maxEntries)
+		maxEntries = 2
+
+		for _, repo := range []string{"a", "b", "c"} {
+			Expect(service.Add(Clone{Org: "acme", Repo: repo, Path: "/src/" + repo})).To(Succeed())
+		}
+
+		entries, _ := service.List()
+
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Repo).To(Equal("c"))
+	})
+
+	It("should find the existing clone by the full name or the repository name", func() {
+		root := GinkgoT().TempDir()
+
+		acme, globex := filepath.Join(root, "acme", "api"), filepath.Join(root, "globex", "api")
+
+		Expect(os.MkdirAll(acme, 0755)).To(Succeed())
+		Expect(os.MkdirAll(globex, 0755)).To(Succeed())
+
+		entries := []Clone{{Org: "acme", Repo: "api", Path: acme}, {Org: "globex", Repo: "api", Path: globex}}
+
+		e, ok := Find(entries, "Globex/API")
+
+		Expect(ok).To(BeTrue())
+		Expect(e.Path).To(Equal(globex))
+
+		e, ok = Find(entries, "api")
+
+		Expect(ok).To(BeTrue())
+		Expect(e.Path).To(Equal(acme))
+
+		_, ok = Find(entries, "web")
+
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should skip the clones removed from the disk", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "api")
+
+		Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+
+		entries := []Clone{{Org: "acme", Repo: "api", Path: filepath.Join(dir, "removed")}, {Org: "acme", Repo: "api", Path: dir}}
+
+		e, ok := Find(entries, "acme/api")
+
+		Expect(ok).To(BeTrue())
+		Expect(e.Path).To(Equal(dir))
+	})
+})
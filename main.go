@@ -1,13 +1,29 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Aykutfgoktas/orc/cmd"
 )
 
 func main() {
-	if err := cmd.RootCmd.Execute(); err != nil {
-		fmt.Println(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		// After the first signal the default handling is restored, so a second Ctrl-C terminates
+		// the prompts that do not watch the context.
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := cmd.Execute(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		stop()
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,255 @@
+// Package markdown renders the markdown of the READMEs for the terminal. It covers the
+// blocks and the inline formatting READMEs use, not the whole CommonMark specification.
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	bold      = "1"
+	dim       = "2"
+	italic    = "3"
+	underline = "4"
+	blue      = "34"
+	magenta   = "35"
+	cyan      = "36"
+)
+
+// resets turns off each style alone, a full reset would end the outer style of the nested ones.
+var resets = map[string]string{
+	bold:      "22",
+	dim:       "22",
+	italic:    "23",
+	underline: "24",
+	blue:      "39",
+	magenta:   "39",
+	cyan:      "39",
+}
+
+// ruleWidth is the width of the horizontal rules.
+var ruleWidth = 40
+
+var (
+	reComment   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	reFence     = regexp.MustCompile("^\\s*(```|~~~)")
+	reHeading   = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)[\s#]*$`)
+	reSetext    = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	reRule      = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	reQuote     = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	reBullet    = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	reTask      = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	reOrdered   = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	reTableSep  = regexp.MustCompile(`^\s*\|?(\s*:?-+:?\s*\|)+\s*(:?-+:?\s*)?\|?\s*$`)
+	reCode      = regexp.MustCompile("`([^`]+)`")
+	reKept      = regexp.MustCompile("\x00(\\d+)\x00")
+	reAutolink  = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	reTag       = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	reImage     = regexp.MustCompile(`!\[([^\]]*)\](?:\([^)]*\)|\[[^\]]*\])?`)
+	reLink      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	reRefLink   = regexp.MustCompile(`\[([^\]]+)\]\[[^\]]*\]`)
+	reRefDef    = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s+\S+`)
+	reBold      = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	reItalic    = regexp.MustCompile(`(^|[^\w*])[*_]([^*_\s](?:[^*_]*[^*_\s])?)[*_]($|[^\w*])`)
+	reEmptyRuns = regexp.MustCompile(`\n{3,}`)
+)
+
+type renderer struct {
+	color bool
+}
+
+// Render returns the markdown formatted for the terminal, with ANSI colors when color is set
+// and as plain text without the markdown syntax otherwise.
+func Render(src string, color bool) string {
+	r := renderer{color: color}
+
+	lines := strings.Split(reComment.ReplaceAllString(Sanitize(src), ""), "\n")
+
+	var out []string
+
+	fenced := false
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if reFence.MatchString(line) {
+			fenced = !fenced
+			continue
+		}
+
+		if fenced {
+			out = append(out, "    "+r.style(line, cyan))
+			continue
+		}
+
+		// A text line underlined with = or - is a heading.
+		if i+1 < len(lines) && strings.TrimSpace(line) != "" && reSetext.MatchString(lines[i+1]) && r.isText(line) {
+			level := 2
+
+			if strings.HasPrefix(strings.TrimSpace(lines[i+1]), "=") {
+				level = 1
+			}
+
+			out = append(out, r.heading(level, line))
+			i++
+
+			continue
+		}
+
+		// The definitions of the reference links are not shown, the links show only their text.
+		if reRefDef.MatchString(line) {
+			continue
+		}
+
+		out = append(out, strings.TrimRight(r.block(line), " \t"))
+	}
+
+	text := reEmptyRuns.ReplaceAllString(strings.Join(out, "\n"), "\n\n")
+
+	return strings.Trim(text, "\n") + "\n"
+}
+
+// Sanitize drops the control characters except the newlines and tabs from the text of other people's
+// repositories, so the escape sequences in it cannot rewrite the terminal.
+func Sanitize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, text)
+}
+
+// isText reports whether the line is a paragraph line and not the start of another block.
+func (r renderer) isText(line string) bool {
+	return !reHeading.MatchString(line) && !reQuote.MatchString(line) &&
+		!reBullet.MatchString(line) && !reOrdered.MatchString(line) && !reRule.MatchString(line)
+}
+
+func (r renderer) block(line string) string {
+	if m := reHeading.FindStringSubmatch(line); m != nil {
+		return r.heading(len(m[1]), m[2])
+	}
+
+	if reRule.MatchString(line) {
+		return r.style(strings.Repeat("─", ruleWidth), dim)
+	}
+
+	if m := reQuote.FindStringSubmatch(line); m != nil {
+		return r.style("│ ", dim) + r.style(r.inline(m[1]), italic)
+	}
+
+	if m := reBullet.FindStringSubmatch(line); m != nil {
+		if t := reTask.FindStringSubmatch(m[2]); t != nil {
+			box := "☐ "
+
+			if t[1] != " " {
+				box = "☑ "
+			}
+
+			return m[1] + box + r.inline(t[2])
+		}
+
+		return m[1] + "• " + r.inline(m[2])
+	}
+
+	if m := reOrdered.FindStringSubmatch(line); m != nil {
+		return m[1] + m[2] + " " + r.inline(m[3])
+	}
+
+	if strings.Contains(line, "|") && reTableSep.MatchString(line) {
+		return r.style(strings.Repeat("─", len(strings.TrimSpace(line))), dim)
+	}
+
+	return r.inline(line)
+}
+
+func (r renderer) heading(level int, text string) string {
+	text = renderer{}.inline(strings.TrimSpace(text))
+
+	if level == 1 {
+		return r.style(strings.ToUpper(text), bold, underline, magenta)
+	}
+
+	return r.style(text, bold, magenta)
+}
+
+// inline formats the code spans, links, images, bold and italic text of the line and drops the HTML tags.
+// The code spans and the URLs are rendered first and kept aside behind placeholders, so the emphasis
+// passes never rewrite them while the emphasis around them still applies. The sanitized text has no
+// NUL characters of its own to be mistaken for the placeholders.
+func (r renderer) inline(line string) string {
+	var kept []string
+
+	keep := func(rendered string) string {
+		kept = append(kept, rendered)
+		return fmt.Sprintf("\x00%d\x00", len(kept)-1)
+	}
+
+	line = reCode.ReplaceAllStringFunc(line, func(s string) string {
+		return keep(r.style(reCode.FindStringSubmatch(s)[1], cyan))
+	})
+
+	line = reAutolink.ReplaceAllString(line, "[$1]($1)")
+	line = reTag.ReplaceAllString(line, "")
+	line = reImage.ReplaceAllString(line, "$1")
+
+	line = reLink.ReplaceAllStringFunc(line, func(s string) string {
+		m := reLink.FindStringSubmatch(s)
+
+		if m[1] == m[2] {
+			return keep(r.style(m[2], underline, blue))
+		}
+
+		return m[1] + " " + keep(r.style("("+m[2]+")", blue))
+	})
+
+	line = reRefLink.ReplaceAllString(line, "$1")
+
+	line = reBold.ReplaceAllStringFunc(line, func(s string) string {
+		m := reBold.FindStringSubmatch(s)
+		return r.style(m[1]+m[2], bold)
+	})
+
+	line = reItalic.ReplaceAllStringFunc(line, func(s string) string {
+		m := reItalic.FindStringSubmatch(s)
+		return m[1] + r.style(m[2], italic) + m[3]
+	})
+
+	return reKept.ReplaceAllStringFunc(line, func(s string) string {
+		i, _ := strconv.Atoi(reKept.FindStringSubmatch(s)[1])
+		return kept[i]
+	})
+}
+
+// style wraps the text in the ANSI codes and the codes turning them off, the text is returned as is without the colors.
+func (r renderer) style(text string, codes ...string) string {
+	if !r.color || text == "" {
+		return text
+	}
+
+	var off []string
+
+	for _, c := range codes {
+		if o := resets[c]; !contains(off, o) {
+			off = append(off, o)
+		}
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m" + text + "\x1b[" + strings.Join(off, ";") + "m"
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMarkdown(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Markdown Suite")
+}
+
+var _ = Describe("Render", func() {
+	It("should render the blocks as plain text without the colors", func() {
+		src := "# Orc\n\nClones **every** repository.\n\nUsage\n-----\n\n" +
+			"- one\n  * nested\n- [x] done\n1. first\n\n> note\n\n---\n"
+
+		Expect(Render(src, false)).To(Equal("ORC\n\nClones every repository.\n\nUsage\n\n" +
+			"• one\n  • nested\n☑ done\n1. first\n\n│ note\n\n" + "────────────────────────────────────────\n"))
+	})
+
+	It("should keep the code blocks as they are", func() {
+		src := "```sh\norc **clone** # [x](y)\n```\n"
+
+		Expect(Render(src, false)).To(Equal("    orc **clone** # [x](y)\n"))
+	})
+
+	It("should render the links, images and code spans", func() {
+		Expect(Render("[![Build](https://ci/badge.svg)](https://ci) see [docs](https://docs) and `a*b*c`", false)).
+			To(Equal("Build (https://ci) see docs (https://docs) and a*b*c\n"))
+		Expect(Render("<https://example.com>", false)).To(Equal("https://example.com\n"))
+		Expect(Render("[![test-img]][test-url] [guide][]\n\n[test-url]: https://ci\n[test-img]: https://ci/badge.svg", false)).
+			To(Equal("test-img guide\n"))
+	})
+
+	It("should drop the HTML tags and comments", func() {
+		src := "<p align=\"center\">\n  <img src=\"logo.png\">\n</p>\n<!-- hidden\ncomment -->\n\n\n\nText<br>\n"
+
+		Expect(Render(src, false)).To(Equal("Text\n"))
+	})
+
+	It("should not take the snake case names as italic", func() {
+		Expect(Render("set snake_case_name and _this_ or *that*", false)).
+			To(Equal("set snake_case_name and this or that\n"))
+	})
+
+	It("should drop the table separator rows", func() {
+		Expect(Render("| a | b |\n|---|:-:|\n| 1 | 2 |", false)).To(Equal("| a | b |\n─────────\n| 1 | 2 |\n"))
+	})
+
+	It("should color the text for the terminal", func() {
+		Expect(Render("## Install `orc`", true)).To(Equal("\x1b[1;35mInstall orc\x1b[22;39m\n"))
+		Expect(Render("run `orc` **now**", true)).To(Equal("run \x1b[36morc\x1b[39m \x1b[1mnow\x1b[22m\n"))
+	})
+
+	Describe("nesting", func() {
+		It("should keep the emphasis around the code spans", func() {
+			Expect(Render("**run `orc clone` now**", true)).
+				To(Equal("\x1b[1mrun \x1b[36morc clone\x1b[39m now\x1b[22m\n"))
+		})
+
+		It("should not format the emphasis markers inside the code spans", func() {
+			Expect(Render("use `**kwargs` and `_private_`", false)).To(Equal("use **kwargs and _private_\n"))
+		})
+
+		It("should keep the emphasis around the links", func() {
+			Expect(Render("**see [docs](https://x.io) now**", true)).
+				To(Equal("\x1b[1msee docs \x1b[34m(https://x.io)\x1b[39m now\x1b[22m\n"))
+			Expect(Render("_see [the **guide**](https://x.io)_", false)).To(Equal("see the guide (https://x.io)\n"))
+		})
+
+		It("should not format the emphasis markers inside the URLs", func() {
+			Expect(Render("[x](https://x.io/_a_/*b*/__c__) and <https://x.io/**d**>", false)).
+				To(Equal("x (https://x.io/_a_/*b*/__c__) and https://x.io/**d**\n"))
+		})
+
+		It("should nest italic inside bold", func() {
+			Expect(Render("**very _much_ so**", true)).
+				To(Equal("\x1b[1mvery \x1b[3mmuch\x1b[23m so\x1b[22m\n"))
+		})
+
+		It("should leave the unclosed markers as text", func() {
+			Expect(Render("a `b and **c", false)).To(Equal("a `b and **c\n"))
+		})
+
+		It("should render the emphasis of the quotes and list items", func() {
+			Expect(Render("> **note** `x`\n- [**a**](https://x.io)", false)).To(Equal("│ note x\n• a (https://x.io)\n"))
+		})
+	})
+
+	It("should drop the control characters of the source", func() {
+		src := "# Title\x1b]0;owned\x07\r\n\x1b[2Jtext\u009b31m\tand\x00 more\r\n"
+
+		Expect(Render(src, false)).To(Equal("TITLE]0;OWNED\n[2Jtext31m\tand more\n"))
+		Expect(Render(src, true)).NotTo(ContainSubstring("\x1b]"))
+		Expect(Render(src, true)).NotTo(ContainSubstring("\x1b[2J"))
+	})
+})
+
+var _ = Describe("Sanitize", func() {
+	It("should keep the newlines and tabs and drop the other control characters", func() {
+		Expect(Sanitize("a\tb\nc\x1b]8;;https://evil.example\x1b\\d\re\u009bf\u007f")).
+			To(Equal("a\tb\nc]8;;https://evil.example\\def"))
+	})
+})
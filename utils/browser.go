@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// browserStartup is how long the browser command is watched for an early failure,
+// browsers started directly from $BROWSER keep running after it.
+var browserStartup = time.Second
+
+// OpenBrowser opens the URL with the command in $BROWSER, or the default browser of the system.
+// The command is waited for in the background, so it is reaped however long it runs.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+
+	switch browser := os.Getenv("BROWSER"); {
+	case browser != "":
+		cmd = exec.Command(browser, url)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("open", url)
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if msg := strings.TrimSpace(stderr.String()); err != nil && msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return err
+	case <-time.After(browserStartup):
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoClipboard is returned when none of the known clipboard commands is installed.
+var ErrNoClipboard = errors.New("no clipboard command found, install wl-clipboard, xclip or xsel")
+
+// CopyToClipboard copies the text with the clipboard command of the system.
+func CopyToClipboard(text string) error {
+	var commands [][]string
+
+	switch runtime.GOOS {
+	case "darwin":
+		commands = [][]string{{"pbcopy"}}
+	case "windows":
+		commands = [][]string{{"clip"}}
+	default:
+		commands = [][]string{{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	}
+
+	for _, c := range commands {
+		if _, err := exec.LookPath(c[0]); err != nil {
+			continue
+		}
+
+		cmd := exec.Command(c[0], c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+
+		return cmd.Run()
+	}
+
+	return ErrNoClipboard
+}
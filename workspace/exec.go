@@ -0,0 +1,99 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+// Run runs the command in the repository and returns its exit code, -1 when it could not be started
+// or was killed. A single argument is run by the shell so it can chain commands.
+func (r Repo) Run(ctx context.Context, args []string, stdout, stderr io.Writer) (int, error) {
+	if len(args) == 1 {
+		if runtime.GOOS == "windows" {
+			args = []string{"cmd", "/C", args[0]}
+		} else {
+			args = []string{"sh", "-c", args[0]}
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = r.Path
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case err == nil:
+		return 0, nil
+	case ctx.Err() != nil:
+		return -1, ctx.Err()
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode(), err
+	default:
+		return -1, err
+	}
+}
+
+// PrefixWriter writes every line with the prefix. The writers sharing the lock write whole lines,
+// so the output of the commands running in parallel does not mix within a line.
+type PrefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func NewPrefixWriter(out io.Writer, mu *sync.Mutex, prefix string) *PrefixWriter {
+	return &PrefixWriter{
+		mu:     mu,
+		out:    out,
+		prefix: []byte(prefix),
+	}
+}
+
+func (w *PrefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+
+		if i < 0 {
+			return len(p), nil
+		}
+
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the last line when the output does not end with a newline.
+func (w *PrefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := append(w.buf, '\n')
+	w.buf = nil
+
+	return w.writeLine(line)
+}
+
+func (w *PrefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.out.Write(append(append([]byte{}, w.prefix...), line...))
+
+	return err
+}
@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exec", func() {
+	var (
+		ctx  context.Context
+		repo Repo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = Repo{Path: GinkgoT().TempDir()}
+	})
+
+	It("should run the single argument with the shell in the repository", func() {
+		var stdout bytes.Buffer
+
+		code, err := repo.Run(ctx, []string{"pwd && echo done"}, &stdout, &stdout)
+
+		Expect(err).To(BeNil())
+		Expect(code).To(Equal(0))
+		Expect(stdout.String()).To(ContainSubstring("done"))
+	})
+
+	It("should return the exit code of the command", func() {
+		code, err := repo.Run(ctx, []string{"sh", "-c", "exit 3"}, nil, nil)
+
+		Expect(err).NotTo(BeNil())
+		Expect(code).To(Equal(3))
+	})
+
+	It("should return -1 when the command does not exist", func() {
+		code, err := repo.Run(ctx, []string{"orc-command-that-does-not-exist", "x"}, nil, nil)
+
+		Expect(err).NotTo(BeNil())
+		Expect(code).To(Equal(-1))
+	})
+
+	It("should prefix every line and flush the last one", func() {
+		var out bytes.Buffer
+		var mu sync.Mutex
+
+		w := NewPrefixWriter(&out, &mu, "[api] ")
+
+		_, _ = w.Write([]byte("first\nsec"))
+		_, _ = w.Write([]byte("ond\nlast"))
+
+		Expect(out.String()).To(Equal("[api] first\n[api] second\n"))
+
+		Expect(w.Flush()).To(Succeed())
+		Expect(out.String()).To(Equal("[api] first\n[api] second\n[api] last\n"))
+	})
+})
@@ -0,0 +1,244 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Aykutfgoktas/orc/client"
+)
+
+// scanDepth is how deep the repositories are looked up, the repositories are either directly
+// in the workspace or in a directory per organization.
+var scanDepth = 2
+
+// Repo is a git repository cloned in the workspace, the host, owner and name come from the origin remote.
+// ID is the GitHub repository id recorded when orc cloned it, zero otherwise.
+type Repo struct {
+	Path   string
+	Remote string
+	Host   string
+	Owner  string
+	Name   string
+	ID     int64
+}
+
+// FullName returns owner/name, or the directory name when the origin remote is not a GitHub repository.
+func (r Repo) FullName() string {
+	if r.Owner == "" {
+		return filepath.Base(r.Path)
+	}
+
+	return r.Owner + "/" + r.Name
+}
+
+// Scan returns the git repositories in the root directory and in its subdirectories,
+// the repositories are not descended into and hidden directories are skipped.
+func Scan(ctx context.Context, root string) ([]Repo, error) {
+	var repos []Repo
+
+	if err := scan(ctx, root, scanDepth, &repos); err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
+func scan(ctx context.Context, dir string, depth int, repos *[]Repo) error {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			*repos = append(*repos, Open(ctx, path))
+
+			continue
+		}
+
+		if depth > 1 {
+			if err := scan(ctx, path, depth-1, repos); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Open returns the repository in the directory.
+func Open(ctx context.Context, path string) Repo {
+	repo := Repo{Path: path}
+
+	// Both values are optional, git config exits with 1 when none of them is set.
+	out, _ := Git(ctx, path, "config", "--get-regexp", `^(remote\.origin\.url|`+regexp.QuoteMeta(client.IDConfig)+`)$`)
+
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+
+		if key == client.IDConfig {
+			repo.ID, _ = strconv.ParseInt(value, 10, 64)
+		} else if key != "" {
+			repo.Remote = value
+		}
+	}
+
+	repo.Host, repo.Owner, repo.Name = ParseRemote(repo.Remote)
+
+	return repo
+}
+
+// SetID records the GitHub repository id in the git configuration of the repository.
+func (r *Repo) SetID(ctx context.Context, id int64) error {
+	if _, err := Git(ctx, r.Path, "config", client.IDConfig, strconv.FormatInt(id, 10)); err != nil {
+		return err
+	}
+
+	r.ID = id
+
+	return nil
+}
+
+// SetRemote points the origin remote to the repository with the owner and name, keeping the host and the protocol.
+func (r *Repo) SetRemote(ctx context.Context, owner, name string) error {
+	remote := RewriteRemote(r.Remote, owner, name)
+
+	if remote == "" {
+		return fmt.Errorf("origin remote %s is not a GitHub repository", r.Remote)
+	}
+
+	if _, err := Git(ctx, r.Path, "remote", "set-url", "origin", remote); err != nil {
+		return err
+	}
+
+	r.Remote, r.Owner, r.Name = remote, owner, name
+
+	return nil
+}
+
+// EnsureRemote adds the remote next to origin, like the upstream of a fork,
+// an existing remote with the name is pointed to the URL.
+func (r Repo) EnsureRemote(ctx context.Context, name, url string) error {
+	current, err := Git(ctx, r.Path, "remote", "get-url", name)
+
+	switch {
+	case err != nil:
+		_, err = Git(ctx, r.Path, "remote", "add", name, url)
+	case current != url:
+		_, err = Git(ctx, r.Path, "remote", "set-url", name, url)
+	}
+
+	return err
+}
+
+// RewriteRemote returns the remote URL with the owner and name replaced, an empty string
+// when the URL is not in the owner/name form.
+func RewriteRemote(remote, owner, name string) string {
+	if _, o, n := ParseRemote(remote); o == "" || n == "" {
+		return ""
+	}
+
+	suffix := ""
+
+	if strings.HasSuffix(remote, ".git") {
+		suffix = ".git"
+	}
+
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		u.Path = "/" + owner + "/" + name + suffix
+
+		return u.String()
+	}
+
+	return remote[:strings.LastIndex(remote, ":")+1] + owner + "/" + name + suffix
+}
+
+// ParseRemote returns the host, owner and name of the SSH, scp-like or HTTPS remote URL,
+// empty strings when the URL is not in the owner/name form.
+func ParseRemote(remote string) (host, owner, name string) {
+	var path string
+
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 && strings.Contains(remote, ":") {
+		rest := remote[at+1:]
+		i := strings.Index(rest, ":")
+		host, path = rest[:i], rest[i+1:]
+	} else {
+		return "", "", ""
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(path, ".git"), "/"), "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", ""
+	}
+
+	return host, parts[0], parts[1]
+}
+
+// Git runs the git command in the directory and returns the trimmed output,
+// the error contains the message git writes to stderr.
+func Git(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ForEach calls fn for every item with at most jobs calls running at the same time,
+// it returns when all the calls are finished.
+func ForEach[T any](items []T, jobs int, fn func(i int, item T)) {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, jobs)
+
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fn(i, item)
+		}(i, item)
+	}
+
+	wg.Wait()
+}
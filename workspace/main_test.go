@@ -0,0 +1,205 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkspace(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workspace Suite")
+}
+
+// git runs the git command in the directory for the test setup.
+func git(dir string, args ...string) string {
+	args = append([]string{"-c", "user.name=orc", "-c", "user.email=orc@example.com", "-c", "init.defaultBranch=main"}, args...)
+
+	out, err := Git(context.Background(), dir, args...)
+	Expect(err).To(BeNil())
+
+	return out
+}
+
+var _ = Describe("Workspace", func() {
+	var (
+		ctx  context.Context
+		root string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = GinkgoT().TempDir()
+	})
+
+	Describe("Scan", func() {
+		It("should find the repositories in the workspace and in the organization directories", func() {
+			git(root, "init", "-q", "api")
+			git(root, "-C", "api", "remote", "add", "origin", "git@github.com:acme/api.git")
+			Expect(os.MkdirAll(filepath.Join(root, "globex"), 0700)).To(Succeed())
+			git(root, "init", "-q", "globex/web")
+			git(root, "init", "-q", "api/vendor/lib")
+			Expect(os.MkdirAll(filepath.Join(root, "notes"), 0700)).To(Succeed())
+
+			repos, err := Scan(ctx, root)
+
+			Expect(err).To(BeNil())
+			Expect(repos).To(HaveLen(2))
+			Expect(repos[0]).To(Equal(Repo{
+				Path:   filepath.Join(root, "api"),
+				Remote: "git@github.com:acme/api.git",
+				Host:   "github.com",
+				Owner:  "acme",
+				Name:   "api",
+			}))
+			Expect(repos[1].FullName()).To(Equal("web"))
+		})
+	})
+
+	DescribeTable("ParseRemote",
+		func(remote, host, owner, name string) {
+			h, o, n := ParseRemote(remote)
+
+			Expect([]string{h, o, n}).To(Equal([]string{host, owner, name}))
+		},
+		Entry("scp-like", "git@github.com:acme/api.git", "github.com", "acme", "api"),
+		Entry("ssh", "ssh://git@github.example.com:2222/acme/api.git", "github.example.com", "acme", "api"),
+		Entry("https", "https://github.com/acme/api", "github.com", "acme", "api"),
+		Entry("local path", "/srv/git/api.git", "", "", ""),
+		Entry("empty", "", "", "", ""),
+	)
+
+	DescribeTable("RewriteRemote",
+		func(remote, expected string) {
+			Expect(RewriteRemote(remote, "globex", "web")).To(Equal(expected))
+		},
+		Entry("scp-like", "git@github.com:acme/api.git", "git@github.com:globex/web.git"),
+		Entry("ssh", "ssh://git@github.example.com:2222/acme/api.git", "ssh://git@github.example.com:2222/globex/web.git"),
+		Entry("https without suffix", "https://github.com/acme/api", "https://github.com/globex/web"),
+		Entry("local path", "/srv/git/api.git", ""),
+	)
+
+	Describe("Repository id and remote", func() {
+		It("should record the id and rewrite the origin remote", func() {
+			git(root, "init", "-q", "api")
+			git(root, "-C", "api", "remote", "add", "origin", "git@github.com:acme/api.git")
+
+			repo := Open(ctx, filepath.Join(root, "api"))
+
+			Expect(repo.ID).To(BeZero())
+			Expect(repo.SetID(ctx, 42)).To(Succeed())
+			Expect(repo.SetRemote(ctx, "globex", "web")).To(Succeed())
+
+			repo = Open(ctx, filepath.Join(root, "api"))
+
+			Expect(repo.ID).To(Equal(int64(42)))
+			Expect(repo.Remote).To(Equal("git@github.com:globex/web.git"))
+			Expect(repo.FullName()).To(Equal("globex/web"))
+		})
+
+		It("should add the remote next to origin and keep it up to date", func() {
+			git(root, "init", "-q", "api")
+			git(root, "-C", "api", "remote", "add", "origin", "git@github.com:jane/api.git")
+
+			repo := Open(ctx, filepath.Join(root, "api"))
+
+			Expect(repo.EnsureRemote(ctx, "upstream", "git@github.com:acme/api.git")).To(Succeed())
+			Expect(git(repo.Path, "remote", "get-url", "upstream")).To(Equal("git@github.com:acme/api.git"))
+			Expect(Open(ctx, repo.Path).FullName()).To(Equal("jane/api"))
+
+			Expect(repo.EnsureRemote(ctx, "upstream", "git@github.com:acme/api.git")).To(Succeed())
+			Expect(repo.EnsureRemote(ctx, "upstream", "git@github.com:globex/api.git")).To(Succeed())
+			Expect(git(repo.Path, "remote", "get-url", "upstream")).To(Equal("git@github.com:globex/api.git"))
+		})
+	})
+
+	Describe("Status", func() {
+		It("should report the branch, upstream, changes and stashes", func() {
+			git(root, "init", "-q", "--bare", "upstream.git")
+			git(root, "clone", "-q", filepath.Join(root, "upstream.git"), "api")
+
+			dir := filepath.Join(root, "api")
+
+			git(dir, "commit", "-q", "--allow-empty", "-m", "first")
+			git(dir, "push", "-q", "origin", "main")
+			git(dir, "commit", "-q", "--allow-empty", "-m", "second")
+
+			Expect(os.WriteFile(filepath.Join(dir, "stashed"), []byte("a"), 0600)).To(Succeed())
+			git(dir, "stash", "-q", "-u")
+			Expect(os.WriteFile(filepath.Join(dir, "new"), []byte("a"), 0600)).To(Succeed())
+
+			st, err := Open(ctx, dir).Status(ctx)
+
+			Expect(err).To(BeNil())
+			Expect(st.Branch).To(Equal("main"))
+			Expect(st.Upstream).To(Equal("origin/main"))
+			Expect(st.Ahead).To(Equal(1))
+			Expect(st.Behind).To(Equal(0))
+			Expect(st.Dirty).To(Equal(1))
+			Expect(st.Stashes).To(Equal(1))
+			Expect(st.LastFetch.IsZero()).To(BeTrue())
+
+			git(dir, "fetch", "-q")
+
+			st, err = Open(ctx, dir).Status(ctx)
+
+			Expect(err).To(BeNil())
+			Expect(st.LastFetch.IsZero()).To(BeFalse())
+		})
+	})
+
+	Describe("Unpushed", func() {
+		It("should count the commits of the local branches missing on the remotes", func() {
+			git(root, "init", "-q", "--bare", "upstream.git")
+			git(root, "clone", "-q", filepath.Join(root, "upstream.git"), "api")
+
+			dir := filepath.Join(root, "api")
+
+			git(dir, "commit", "-q", "--allow-empty", "-m", "first")
+			git(dir, "push", "-q", "origin", "main")
+
+			Expect(Open(ctx, dir).Unpushed(ctx)).To(Equal(0))
+
+			git(dir, "checkout", "-q", "-b", "feature")
+			git(dir, "commit", "-q", "--allow-empty", "-m", "second")
+			git(dir, "checkout", "-q", "main")
+
+			Expect(Open(ctx, dir).Unpushed(ctx)).To(Equal(1))
+		})
+	})
+
+	Describe("ForEach", func() {
+		It("should call the function for every repository with limited concurrency", func() {
+			repos := []Repo{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+			names := make([]string, len(repos))
+
+			var running, peak int32
+
+			ForEach(repos, 2, func(i int, repo Repo) {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+
+				for {
+					p := atomic.LoadInt32(&peak)
+
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+
+				names[i] = repo.Name
+			})
+
+			sort.Strings(names)
+
+			Expect(names).To(Equal([]string{"a", "b", "c", "d"}))
+			Expect(peak).To(BeNumerically("<=", 2))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Layouts of the repositories in the workspace.
+const (
+	// LayoutFlat clones the repositories directly into the workspace.
+	LayoutFlat = "flat"
+	// LayoutOrg clones the repositories into a directory per organization.
+	LayoutOrg = "org"
+)
+
+// ErrDirty is returned when the repository is not updated because it has local changes.
+var ErrDirty = errors.New("the working tree has local changes")
+
+// Manifest is the list of the repositories of a workspace.
+type Manifest struct {
+	Layout       string          `json:"layout,omitempty" yaml:"layout,omitempty" toml:"layout,omitempty"`
+	Repositories []ManifestEntry `json:"repositories" yaml:"repositories" toml:"repositories"`
+}
+
+// ManifestEntry is a repository of the manifest with its clone options. Path is relative to the workspace,
+// the manifest layout decides it when it is empty.
+type ManifestEntry struct {
+	Org          string `json:"org" yaml:"org" toml:"org"`
+	Repo         string `json:"repo" yaml:"repo" toml:"repo"`
+	Branch       string `json:"branch,omitempty" yaml:"branch,omitempty" toml:"branch,omitempty"`
+	Path         string `json:"path,omitempty" yaml:"path,omitempty" toml:"path,omitempty"`
+	Depth        int    `json:"depth,omitempty" yaml:"depth,omitempty" toml:"depth,omitempty"`
+	SingleBranch bool   `json:"single_branch,omitempty" yaml:"single_branch,omitempty" toml:"single_branch,omitempty"`
+}
+
+// Validate checks the layout and that every entry names a repository.
+func (m *Manifest) Validate() error {
+	if m.Layout != "" && m.Layout != LayoutFlat && m.Layout != LayoutOrg {
+		return fmt.Errorf("unknown layout %s, it is either %s or %s", m.Layout, LayoutFlat, LayoutOrg)
+	}
+
+	for i, e := range m.Repositories {
+		if e.Org == "" || e.Repo == "" {
+			return fmt.Errorf("repository %d of the manifest has no org or repo", i+1)
+		}
+
+		if !pathComponent(e.Org) || !pathComponent(e.Repo) {
+			return fmt.Errorf("repository %d of the manifest has an invalid org or repo %s/%s", i+1, e.Org, e.Repo)
+		}
+
+		if filepath.IsAbs(e.Path) {
+			return fmt.Errorf("path of %s/%s is not relative to the workspace", e.Org, e.Repo)
+		}
+
+		// A shared manifest must not clone or update anything outside the workspace.
+		rel, err := filepath.Rel(manifestRoot, m.Dir(manifestRoot, e))
+
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("path of %s/%s is outside of the workspace", e.Org, e.Repo)
+		}
+	}
+
+	return nil
+}
+
+// manifestRoot is the workspace the paths of the entries are validated against, any root gives the same result.
+var manifestRoot = filepath.Join(string(filepath.Separator), "workspace")
+
+// pathComponent reports whether the name is a single directory name, the org and repo name directories.
+func pathComponent(name string) bool {
+	return name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// Dir returns the directory of the entry in the workspace.
+func (m *Manifest) Dir(root string, e ManifestEntry) string {
+	switch {
+	case e.Path != "":
+		return filepath.Join(root, e.Path)
+	case m.Layout == LayoutOrg:
+		return filepath.Join(root, e.Org, e.Repo)
+	default:
+		return filepath.Join(root, e.Repo)
+	}
+}
+
+// Update fetches the repository, switches to the branch when it is given and fast-forwards it to its upstream.
+// Repositories with local changes are only fetched and ErrDirty is returned.
+func (r Repo) Update(ctx context.Context, branch string) error {
+	if _, err := Git(ctx, r.Path, "fetch", "--quiet", "--prune"); err != nil {
+		return err
+	}
+
+	st, err := r.Status(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if st.Dirty > 0 {
+		return ErrDirty
+	}
+
+	if branch != "" && st.Branch != branch {
+		if _, err := Git(ctx, r.Path, "checkout", "--quiet", branch); err != nil {
+			return err
+		}
+
+		if st, err = r.Status(ctx); err != nil {
+			return err
+		}
+	}
+
+	if st.Upstream == "" || st.Behind == 0 {
+		return nil
+	}
+
+	_, err = Git(ctx, r.Path, "merge", "--ff-only", "--quiet", "@{upstream}")
+
+	return err
+}
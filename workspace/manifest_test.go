@@ -0,0 +1,103 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest", func() {
+	It("should place the repositories by the layout unless the path is given", func() {
+		m := Manifest{Layout: LayoutOrg}
+
+		Expect(m.Dir("/src", ManifestEntry{Org: "acme", Repo: "api"})).To(Equal("/src/acme/api"))
+		Expect(m.Dir("/src", ManifestEntry{Org: "acme", Repo: "api", Path: "backend/api"})).To(Equal("/src/backend/api"))
+
+		m.Layout = LayoutFlat
+
+		Expect(m.Dir("/src", ManifestEntry{Org: "acme", Repo: "api"})).To(Equal("/src/api"))
+	})
+
+	It("should reject the unknown layouts and incomplete entries", func() {
+		Expect((&Manifest{Layout: "nested"}).Validate()).NotTo(Succeed())
+		Expect((&Manifest{Repositories: []ManifestEntry{{Org: "acme"}}}).Validate()).NotTo(Succeed())
+		Expect((&Manifest{Repositories: []ManifestEntry{{Org: "acme", Repo: "api", Path: "/tmp/api"}}}).Validate()).NotTo(Succeed())
+		Expect((&Manifest{Repositories: []ManifestEntry{{Org: "acme", Repo: "api"}}}).Validate()).To(Succeed())
+	})
+
+	DescribeTable("should reject the entries outside of the workspace",
+		func(layout string, e ManifestEntry) {
+			m := Manifest{Layout: layout, Repositories: []ManifestEntry{e}}
+
+			Expect(m.Validate()).NotTo(Succeed())
+		},
+		Entry("path escaping the workspace", LayoutFlat, ManifestEntry{Org: "acme", Repo: "api", Path: "../../.ssh/x"}),
+		Entry("path escaping through a directory", LayoutFlat, ManifestEntry{Org: "acme", Repo: "api", Path: "backend/../../x"}),
+		Entry("path of the workspace itself", LayoutFlat, ManifestEntry{Org: "acme", Repo: "api", Path: "."}),
+		Entry("repo of the parent directory", LayoutFlat, ManifestEntry{Org: "acme", Repo: ".."}),
+		Entry("repo of the workspace itself", LayoutFlat, ManifestEntry{Org: "acme", Repo: "."}),
+		Entry("org of the parent directory", LayoutOrg, ManifestEntry{Org: "..", Repo: "api"}),
+		Entry("repo with a separator", LayoutOrg, ManifestEntry{Org: "acme", Repo: "../api"}),
+		Entry("org with a backslash", LayoutOrg, ManifestEntry{Org: `acme\..`, Repo: "api"}),
+	)
+
+	It("should accept the paths inside the workspace", func() {
+		m := Manifest{Repositories: []ManifestEntry{{Org: "acme", Repo: "api", Path: "backend/../api"}, {Org: "acme", Repo: "..api"}}}
+
+		Expect(m.Validate()).To(Succeed())
+	})
+
+	Describe("Update", func() {
+		var (
+			ctx     context.Context
+			root    string
+			dir     string
+			another string
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			root = GinkgoT().TempDir()
+
+			git(root, "init", "-q", "--bare", "upstream.git")
+			git(root, "clone", "-q", filepath.Join(root, "upstream.git"), "another")
+
+			another = filepath.Join(root, "another")
+
+			git(another, "commit", "-q", "--allow-empty", "-m", "first")
+			git(another, "push", "-q", "origin", "main")
+			git(another, "checkout", "-q", "-b", "release")
+			git(another, "push", "-q", "origin", "release")
+			git(another, "checkout", "-q", "main")
+
+			git(root, "clone", "-q", filepath.Join(root, "upstream.git"), "api")
+
+			dir = filepath.Join(root, "api")
+
+			git(another, "commit", "-q", "--allow-empty", "-m", "second")
+			git(another, "push", "-q", "origin", "main")
+		})
+
+		It("should fast-forward the branch to its upstream", func() {
+			Expect(Open(ctx, dir).Update(ctx, "")).To(Succeed())
+
+			Expect(git(dir, "log", "-1", "--format=%s")).To(Equal("second"))
+		})
+
+		It("should switch to the branch", func() {
+			Expect(Open(ctx, dir).Update(ctx, "release")).To(Succeed())
+
+			Expect(git(dir, "branch", "--show-current")).To(Equal("release"))
+		})
+
+		It("should not touch the working tree with local changes", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "new"), []byte("a"), 0600)).To(Succeed())
+
+			Expect(Open(ctx, dir).Update(ctx, "")).To(MatchError(ErrDirty))
+			Expect(git(dir, "log", "-1", "--format=%s")).To(Equal("first"))
+		})
+	})
+})
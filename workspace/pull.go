@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrLocalCommits is returned when the branch of the pull request is not reset because it has commits
+// that are neither in the pull request nor in its earlier checkout.
+var ErrLocalCommits = errors.New("the branch has local commits")
+
+// PullRequestBranch returns the local branch the pull request is checked out to.
+func PullRequestBranch(number int) string {
+	return fmt.Sprintf("pr-%d", number)
+}
+
+// pullRequestRef is the ref recording the head of the pull request at its last checkout,
+// the commits of the branch reachable from it are not local work.
+func pullRequestRef(number int) string {
+	return fmt.Sprintf("refs/orc/pull/%d", number)
+}
+
+// CheckoutPullRequest fetches the head of the pull request from origin and switches to its local branch,
+// the pull requests from forks are fetched the same way. An existing branch is reset to the head, the pull
+// requests are often force-pushed during the review, unless it has local commits which are kept and
+// ErrLocalCommits is returned. Repositories with local changes are not switched and ErrDirty is returned.
+func (r Repo) CheckoutPullRequest(ctx context.Context, number int) (string, error) {
+	st, err := r.Status(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	if st.Dirty > 0 {
+		return "", ErrDirty
+	}
+
+	branch := PullRequestBranch(number)
+	last := pullRequestRef(number)
+
+	if _, err := Git(ctx, r.Path, "fetch", "--quiet", "origin", fmt.Sprintf("pull/%d/head", number)); err != nil {
+		return "", err
+	}
+
+	if _, err := Git(ctx, r.Path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		args := []string{"rev-list", "--count", "refs/heads/" + branch, "--not", "FETCH_HEAD"}
+
+		if _, err := Git(ctx, r.Path, "rev-parse", "--verify", "--quiet", last); err == nil {
+			args = append(args, last)
+		}
+
+		count, err := Git(ctx, r.Path, args...)
+
+		if err != nil {
+			return "", err
+		}
+
+		if count != "0" {
+			return "", fmt.Errorf("%w: %s has %s commits not in the pull request", ErrLocalCommits, branch, count)
+		}
+	}
+
+	if _, err := Git(ctx, r.Path, "checkout", "--quiet", "-B", branch, "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+
+	if _, err := Git(ctx, r.Path, "update-ref", last, "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+
+	return branch, nil
+}
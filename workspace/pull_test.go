@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckoutPullRequest", func() {
+	var (
+		ctx    context.Context
+		root   string
+		dir    string
+		author string
+	)
+
+	// openPullRequest pushes a commit of the fork and points the pull request ref of the upstream to it,
+	// like GitHub does for the pull requests from forks.
+	openPullRequest := func(message string) {
+		git(author, "commit", "-q", "--allow-empty", "-m", message)
+		git(author, "push", "-q", "-f", filepath.Join(root, "upstream.git"), "HEAD:refs/pull/7/head")
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = GinkgoT().TempDir()
+
+		git(root, "init", "-q", "--bare", "upstream.git")
+		git(root, "clone", "-q", filepath.Join(root, "upstream.git"), "fork")
+
+		author = filepath.Join(root, "fork")
+
+		git(author, "commit", "-q", "--allow-empty", "-m", "first")
+		git(author, "push", "-q", "origin", "main")
+
+		git(root, "clone", "-q", filepath.Join(root, "upstream.git"), "api")
+
+		dir = filepath.Join(root, "api")
+
+		git(author, "checkout", "-q", "-b", "feature")
+		openPullRequest("feature")
+	})
+
+	It("should check out the head of the pull request to its branch", func() {
+		branch, err := Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+
+		Expect(err).To(BeNil())
+		Expect(branch).To(Equal("pr-7"))
+		Expect(git(dir, "branch", "--show-current")).To(Equal("pr-7"))
+		Expect(git(dir, "log", "-1", "--format=%s")).To(Equal("feature"))
+	})
+
+	It("should update the branch of an earlier checkout", func() {
+		_, err := Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+		Expect(err).To(BeNil())
+
+		git(dir, "checkout", "-q", "main")
+		openPullRequest("review fixes")
+
+		_, err = Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+
+		Expect(err).To(BeNil())
+		Expect(git(dir, "branch", "--show-current")).To(Equal("pr-7"))
+		Expect(git(dir, "log", "-1", "--format=%s")).To(Equal("review fixes"))
+	})
+
+	It("should reset the branch of an earlier checkout to a force-pushed head", func() {
+		_, err := Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+		Expect(err).To(BeNil())
+
+		git(author, "commit", "-q", "--amend", "--allow-empty", "-m", "rebased feature")
+		git(author, "push", "-q", "-f", filepath.Join(root, "upstream.git"), "HEAD:refs/pull/7/head")
+
+		_, err = Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+
+		Expect(err).To(BeNil())
+		Expect(git(dir, "branch", "--show-current")).To(Equal("pr-7"))
+		Expect(git(dir, "log", "--format=%s")).To(Equal("rebased feature\nfirst"))
+	})
+
+	It("should keep the local commits of an earlier checkout", func() {
+		_, err := Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+		Expect(err).To(BeNil())
+
+		git(dir, "commit", "-q", "--allow-empty", "-m", "local fixup")
+		git(dir, "checkout", "-q", "main")
+		openPullRequest("review fixes")
+
+		_, err = Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+
+		Expect(err).To(MatchError(ErrLocalCommits))
+		Expect(git(dir, "branch", "--show-current")).To(Equal("main"))
+		Expect(git(dir, "log", "-1", "--format=%s", "pr-7")).To(Equal("local fixup"))
+	})
+
+	It("should keep a branch that was not checked out by orc", func() {
+		git(dir, "checkout", "-q", "-b", "pr-7")
+		git(dir, "commit", "-q", "--allow-empty", "-m", "own work")
+		git(dir, "checkout", "-q", "main")
+
+		_, err := Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+
+		Expect(err).To(MatchError(ErrLocalCommits))
+		Expect(git(dir, "log", "-1", "--format=%s", "pr-7")).To(Equal("own work"))
+	})
+
+	It("should not switch the working tree with local changes", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "new"), []byte("a"), 0600)).To(Succeed())
+
+		_, err := Open(ctx, dir).CheckoutPullRequest(ctx, 7)
+
+		Expect(err).To(MatchError(ErrDirty))
+		Expect(git(dir, "branch", "--show-current")).To(Equal("main"))
+	})
+
+	It("should fail for a missing pull request", func() {
+		_, err := Open(ctx, dir).CheckoutPullRequest(ctx, 8)
+
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status is the state of the working tree and of the current branch.
+type Status struct {
+	// Branch is empty when the HEAD is detached.
+	Branch string
+	// Upstream is empty when the branch does not track a remote branch, Ahead and Behind are zero then.
+	Upstream string
+	Ahead    int
+	Behind   int
+	// Dirty is the number of changed and untracked files.
+	Dirty   int
+	Stashes int
+	// LastFetch is zero when the repository has never been fetched.
+	LastFetch time.Time
+}
+
+// Status returns the status of the repository.
+func (r Repo) Status(ctx context.Context) (Status, error) {
+	out, err := Git(ctx, r.Path, "status", "--porcelain=v2", "--branch")
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	st := parseStatus(out)
+
+	stashes, err := Git(ctx, r.Path, "stash", "list")
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	if stashes != "" {
+		st.Stashes = len(strings.Split(stashes, "\n"))
+	}
+
+	fetchHead, err := Git(ctx, r.Path, "rev-parse", "--git-path", "FETCH_HEAD")
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	if !filepath.IsAbs(fetchHead) {
+		fetchHead = filepath.Join(r.Path, fetchHead)
+	}
+
+	if info, err := os.Stat(fetchHead); err == nil {
+		st.LastFetch = info.ModTime()
+	}
+
+	return st, nil
+}
+
+// parseStatus parses the output of git status --porcelain=v2 --branch.
+func parseStatus(out string) Status {
+	var st Status
+
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				st.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			st.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+
+			if len(fields) == 2 {
+				st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			st.Dirty++
+		}
+	}
+
+	return st
+}
+
+// Unpushed returns the number of commits on the local branches that are on no remote branch.
+func (r Repo) Unpushed(ctx context.Context) (int, error) {
+	out, err := Git(ctx, r.Path, "rev-list", "--count", "--branches", "--not", "--remotes")
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(out)
+}